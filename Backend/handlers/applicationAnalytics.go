@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// analyticsIntervals are the accepted ?interval= values, passed to date_trunc
+var analyticsIntervals = map[string]bool{"day": true, "week": true, "month": true}
+
+const maxAnalyticsSkills = 25
+
+// Shared SQL fragments; decisions made before decided_at existed fall back to the row's last update
+const (
+	analyticsInterviewedSQL = "(status = 'interview' OR interview_date <> '' OR interview_starts_at IS NOT NULL)"
+	analyticsDecidedSQL     = "deleted_at IS NULL AND status IN ('accepted', 'approved', 'rejected')"
+	analyticsAcceptedSQL    = "deleted_at IS NULL AND status IN ('accepted', 'approved')"
+	analyticsDecisionSQL    = "EXTRACT(EPOCH FROM COALESCE(decided_at, updated_at) - time_created)"
+)
+
+// applicationFunnel is the status funnel of a set of applications; retracted applications count as applied
+type applicationFunnel struct {
+	Applied                   int64    `json:"applied"`
+	Retracted                 int64    `json:"retracted"`
+	UnderReview               int64    `json:"underReview"`
+	Interviewed               int64    `json:"interviewed"`
+	Waitlisted                int64    `json:"waitlisted"`
+	Decided                   int64    `json:"decided"`
+	Accepted                  int64    `json:"accepted"`
+	Rejected                  int64    `json:"rejected"`
+	MedianDecisionHours       *float64 `json:"medianDecisionHours"`
+	MedianRetractionHours     *float64 `json:"medianRetractionHours"`
+	AcceptanceRate            float64  `json:"acceptanceRate"`            // Accepted / decided
+	InterviewRate             float64  `json:"interviewRate"`             // Interviewed / applied
+	InterviewToAcceptanceRate float64  `json:"interviewToAcceptanceRate"` // Accepted / interviewed
+	RetractionRate            float64  `json:"retractionRate"`            // Retracted / applied
+}
+
+// applicationPeriod counts applications received in one interval
+type applicationPeriod struct {
+	Period    time.Time `json:"period"`
+	Applied   int64     `json:"applied"`
+	Accepted  int64     `json:"accepted"`
+	Rejected  int64     `json:"rejected"`
+	Retracted int64     `json:"retracted"`
+}
+
+// applicantSkill counts distinct applicants listing a skill
+type applicantSkill struct {
+	Skill      string  `json:"skill"`
+	Applicants int64   `json:"applicants"`
+	Share      float64 `json:"share"` // Applicants with the skill / applicants with a profile
+}
+
+// retractionStage counts retractions by the status the application had when it was withdrawn
+type retractionStage struct {
+	Status    string `json:"status"`
+	Retracted int64  `json:"retracted"`
+}
+
+// projectFunnel is the per-project summary in the faculty-wide analytics
+type projectFunnel struct {
+	ProjectID           string   `json:"projectId"`
+	ProjectName         string   `json:"projectName"`
+	Applied             int64    `json:"applied"`
+	Accepted            int64    `json:"accepted"`
+	Decided             int64    `json:"decided"`
+	Retracted           int64    `json:"retracted"`
+	MedianDecisionHours *float64 `json:"medianDecisionHours"`
+	AcceptanceRate      float64  `json:"acceptanceRate"`
+}
+
+// ratio divides safely, returning 0 for an empty denominator
+func ratio(numerator, denominator int64) float64 {
+	if denominator == 0 {
+		return 0
+	}
+	return float64(numerator) / float64(denominator)
+}
+
+// analyticsScope returns a query over every application of the projects, including retracted ones, within the date range
+func analyticsScope(projectIDs []string, filters applicationFilters) *gorm.DB {
+	return filters.apply(config.DB.Unscoped().Model(&models.ProjRequests{}).Where("p_id IN ?", projectIDs))
+}
+
+// buildApplicationAnalytics aggregates the funnel, timeline, skills and retractions of the projects' applications
+func buildApplicationAnalytics(projectIDs []string, filters applicationFilters, interval string) (echo.Map, error) {
+	var funnel applicationFunnel
+	if err := analyticsScope(projectIDs, filters).Select(`
+		COUNT(*) AS applied,
+		COUNT(*) FILTER (WHERE deleted_at IS NOT NULL) AS retracted,
+		COUNT(*) FILTER (WHERE deleted_at IS NULL AND status = 'under_review') AS under_review,
+		COUNT(*) FILTER (WHERE ` + analyticsInterviewedSQL + `) AS interviewed,
+		COUNT(*) FILTER (WHERE deleted_at IS NULL AND status = 'waitlisted') AS waitlisted,
+		COUNT(*) FILTER (WHERE ` + analyticsDecidedSQL + `) AS decided,
+		COUNT(*) FILTER (WHERE ` + analyticsAcceptedSQL + `) AS accepted,
+		COUNT(*) FILTER (WHERE deleted_at IS NULL AND status = 'rejected') AS rejected,
+		percentile_cont(0.5) WITHIN GROUP (ORDER BY ` + analyticsDecisionSQL + `) FILTER (WHERE ` + analyticsDecidedSQL + `) / 3600 AS median_decision_hours,
+		percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM deleted_at - time_created)) FILTER (WHERE deleted_at IS NOT NULL) / 3600 AS median_retraction_hours`).
+		Scan(&funnel).Error; err != nil {
+		return nil, err
+	}
+
+	var interviewedAccepted int64
+	analyticsScope(projectIDs, filters).Where(analyticsInterviewedSQL + " AND " + analyticsAcceptedSQL).Count(&interviewedAccepted)
+
+	funnel.AcceptanceRate = ratio(funnel.Accepted, funnel.Decided)
+	funnel.InterviewRate = ratio(funnel.Interviewed, funnel.Applied)
+	funnel.InterviewToAcceptanceRate = ratio(interviewedAccepted, funnel.Interviewed)
+	funnel.RetractionRate = ratio(funnel.Retracted, funnel.Applied)
+
+	timeline := []applicationPeriod{}
+	if err := analyticsScope(projectIDs, filters).Select(`
+		date_trunc(?, time_created) AS period,
+		COUNT(*) AS applied,
+		COUNT(*) FILTER (WHERE `+analyticsAcceptedSQL+`) AS accepted,
+		COUNT(*) FILTER (WHERE deleted_at IS NULL AND status = 'rejected') AS rejected,
+		COUNT(*) FILTER (WHERE deleted_at IS NOT NULL) AS retracted`, interval).
+		Group("1").Order("1").Scan(&timeline).Error; err != nil {
+		return nil, err
+	}
+
+	retractions := []retractionStage{}
+	if err := analyticsScope(projectIDs, filters).Where("deleted_at IS NOT NULL").
+		Select("status, COUNT(*) AS retracted").Group("status").Order("retracted DESC").
+		Scan(&retractions).Error; err != nil {
+		return nil, err
+	}
+
+	// Skills come from the applicants' student profiles, each applicant counted once
+	var profiled int64
+	config.DB.Table("(?) AS applicant_uids", analyticsScope(projectIDs, filters).Distinct("uid")).
+		Joins("JOIN students ON students.uid = applicant_uids.uid AND students.deleted_at IS NULL").
+		Count(&profiled)
+
+	skills := []applicantSkill{}
+	if err := config.DB.Table("(?) AS applicant_uids", analyticsScope(projectIDs, filters).Distinct("uid")).
+		Joins("JOIN students ON students.uid = applicant_uids.uid AND students.deleted_at IS NULL").
+		Joins("CROSS JOIN LATERAL unnest(students.skills) AS skill_list(skill)").
+		Where("TRIM(skill) <> ''").
+		Select("LOWER(TRIM(skill)) AS skill, COUNT(DISTINCT applicant_uids.uid) AS applicants").
+		Group("1").Order("applicants DESC, skill ASC").Limit(maxAnalyticsSkills).
+		Scan(&skills).Error; err != nil {
+		return nil, err
+	}
+	for i := range skills {
+		skills[i].Share = ratio(skills[i].Applicants, profiled)
+	}
+
+	return echo.Map{
+		"funnel":             funnel,
+		"timeline":           timeline,
+		"interval":           interval,
+		"skills":             skills,
+		"profiledApplicants": profiled,
+		"retractions":        retractions,
+	}, nil
+}
+
+// parseAnalyticsParams reads the date range and ?interval= shared by both analytics endpoints
+func parseAnalyticsParams(c echo.Context) (applicationFilters, string, error) {
+	filters, err := parseApplicationFilters(c)
+	if err != nil {
+		return filters, "", err
+	}
+	// The funnel always spans every status
+	filters.Statuses = nil
+
+	interval := c.QueryParam("interval")
+	if interval == "" {
+		interval = "week"
+	}
+	if !analyticsIntervals[interval] {
+		return filters, "", echo.NewHTTPError(http.StatusBadRequest, "interval must be day, week or month")
+	}
+	return filters, interval, nil
+}
+
+// analyticsParamError converts a parseAnalyticsParams error into the repo's JSON error shape
+func analyticsParamError(c echo.Context, err error) error {
+	if httpErr, ok := err.(*echo.HTTPError); ok {
+		return c.JSON(httpErr.Code, echo.Map{"error": httpErr.Message})
+	}
+	return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+}
+
+// GetProjectAnalytics returns application analytics for a project the professor owns or reviews
+func GetProjectAnalytics(c echo.Context) error {
+	projectID := c.Param("id")
+	if projectID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Project ID is required"})
+	}
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a faculty member
+	if userData.GetUserType() != models.UserTypeFaculty {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can view application analytics"})
+	}
+
+	filters, interval, err := parseAnalyticsParams(c)
+	if err != nil {
+		return analyticsParamError(c, err)
+	}
+
+	project, err := findReviewableProject(projectID, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission to view analytics"})
+	}
+
+	analytics, err := buildApplicationAnalytics([]string{project.ProjectID}, filters, interval)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to compute analytics"})
+	}
+	analytics["projectId"] = project.ProjectID
+	analytics["projectName"] = project.Name
+
+	return c.JSON(http.StatusOK, analytics)
+}
+
+// GetMyApplicationAnalytics returns application analytics across every project the professor owns or reviews
+func GetMyApplicationAnalytics(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a faculty member
+	if userData.GetUserType() != models.UserTypeFaculty {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can view application analytics"})
+	}
+
+	filters, interval, err := parseAnalyticsParams(c)
+	if err != nil {
+		return analyticsParamError(c, err)
+	}
+
+	var projectList []models.Projects
+	if err := config.DB.Select("project_id, name").
+		Where("creator_id = ? OR ? = ANY(reviewers)", userData.GetUID(), userData.GetUID()).
+		Find(&projectList).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch projects"})
+	}
+
+	names := make(map[string]string, len(projectList))
+	projectIDs := make([]string, 0, len(projectList))
+	for _, project := range projectList {
+		names[project.ProjectID] = project.Name
+		projectIDs = append(projectIDs, project.ProjectID)
+	}
+
+	analytics, err := buildApplicationAnalytics(projectIDs, filters, interval)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to compute analytics"})
+	}
+
+	// Per-project breakdown in one grouped query
+	projects := []projectFunnel{}
+	if err := analyticsScope(projectIDs, filters).Select(`
+		p_id AS project_id,
+		COUNT(*) AS applied,
+		COUNT(*) FILTER (WHERE ` + analyticsAcceptedSQL + `) AS accepted,
+		COUNT(*) FILTER (WHERE ` + analyticsDecidedSQL + `) AS decided,
+		COUNT(*) FILTER (WHERE deleted_at IS NOT NULL) AS retracted,
+		percentile_cont(0.5) WITHIN GROUP (ORDER BY ` + analyticsDecisionSQL + `) FILTER (WHERE ` + analyticsDecidedSQL + `) / 3600 AS median_decision_hours`).
+		Group("p_id").Order("applied DESC").Scan(&projects).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to compute analytics"})
+	}
+	for i := range projects {
+		projects[i].ProjectName = names[projects[i].ProjectID]
+		projects[i].AcceptanceRate = ratio(projects[i].Accepted, projects[i].Decided)
+	}
+
+	analytics["projects"] = projects
+	analytics["projectCount"] = len(projectIDs)
+
+	return c.JSON(http.StatusOK, analytics)
+}
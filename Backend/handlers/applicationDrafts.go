@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// expiredDraftRetention is how long expired drafts are kept so students can copy their text out
+const expiredDraftRetention = 30 * 24 * time.Hour
+
+// expireProjectDrafts marks every open draft of a project as expired
+func expireProjectDrafts(db *gorm.DB, projectID string) error {
+	return db.Model(&models.ApplicationDraft{}).
+		Where("p_id = ? AND expired_at IS NULL", projectID).
+		Update("expired_at", time.Now()).Error
+}
+
+// expireClosedProjectDrafts expires drafts of inactive, deleted or past-deadline projects and purges old expired drafts
+func expireClosedProjectDrafts() {
+	now := time.Now()
+
+	// Projects that were deactivated or deleted
+	closed := config.DB.Unscoped().Model(&models.Projects{}).Select("project_id").
+		Where("is_active = ? OR deleted_at IS NOT NULL", false)
+	if err := config.DB.Model(&models.ApplicationDraft{}).
+		Where("expired_at IS NULL AND (p_id IN (?) OR p_id NOT IN (?))", closed, config.DB.Unscoped().Model(&models.Projects{}).Select("project_id")).
+		Update("expired_at", now).Error; err != nil {
+		log.Printf("Failed to expire drafts of closed projects: %v", err)
+	}
+
+	// Projects whose free-text deadline has passed
+	var projects []models.Projects
+	config.DB.Where("deadline IS NOT NULL AND deadline != '' AND project_id IN (?)",
+		config.DB.Model(&models.ApplicationDraft{}).Select("p_id").Where("expired_at IS NULL")).
+		Find(&projects)
+	for _, project := range projects {
+		if isDeadlinePassed(*project.Deadline, now) {
+			if err := expireProjectDrafts(config.DB, project.ProjectID); err != nil {
+				log.Printf("Failed to expire drafts of project %s: %v", project.ProjectID, err)
+			}
+		}
+	}
+
+	// Drop drafts that have been expired for long enough
+	if err := config.DB.Unscoped().Where("expired_at < ?", now.Add(-expiredDraftRetention)).Delete(&models.ApplicationDraft{}).Error; err != nil {
+		log.Printf("Failed to purge expired drafts: %v", err)
+	}
+}
+
+// StartDraftExpiry starts a goroutine that periodically expires drafts of closed projects
+func StartDraftExpiry() {
+	ticker := time.NewTicker(1 * time.Hour)
+	go func() {
+		expireClosedProjectDrafts()
+		for range ticker.C {
+			expireClosedProjectDrafts()
+		}
+	}()
+}
+
+// SaveApplicationDraft creates or updates the student's draft application for a project (autosave)
+func SaveApplicationDraft(c echo.Context) error {
+	projectID := c.Param("id")
+	if projectID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Project ID is required"})
+	}
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can save application drafts"})
+	}
+
+	var draftRequest applicationInput
+	if err := c.Bind(&draftRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid request body"})
+	}
+
+	// Drafts can only be kept for projects that are still open
+	var project models.Projects
+	if err := config.DB.Where("project_id = ? AND is_active = ?", projectID, true).First(&project).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or not active"})
+	}
+	if project.Deadline != nil && isDeadlinePassed(*project.Deadline, time.Now()) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "The deadline for this project has passed"})
+	}
+
+	var applied int64
+	config.DB.Model(&models.ProjRequests{}).Where("uid = ? AND p_id = ?", userData.GetUID(), projectID).Count(&applied)
+	if applied > 0 {
+		return c.JSON(http.StatusConflict, echo.Map{"error": "You have already applied to this project"})
+	}
+
+	draft := models.ApplicationDraft{
+		UID:              userData.GetUID(),
+		PID:              projectID,
+		Availability:     draftRequest.Availability,
+		Motivation:       draftRequest.Motivation,
+		PriorProjects:    draftRequest.PriorProjects,
+		CVLink:           draftRequest.CVLink,
+		PublicationsLink: draftRequest.PublicationsLink,
+	}
+
+	// Upsert on (uid, p_id) so concurrent autosaves never create duplicates
+	if err := config.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "uid"}, {Name: "p_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"availability", "motivation", "prior_projects", "cv_link", "publications_link", "updated_at", "expired_at"}),
+	}).Create(&draft).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save draft"})
+	}
+
+	if err := config.DB.Where("uid = ? AND p_id = ?", userData.GetUID(), projectID).First(&draft).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch saved draft"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Draft saved",
+		"draft":   draft,
+	})
+}
+
+// GetApplicationDraft returns the student's draft application for a project
+func GetApplicationDraft(c echo.Context) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can view application drafts"})
+	}
+
+	var draft models.ApplicationDraft
+	if err := config.DB.Where("uid = ? AND p_id = ?", userData.GetUID(), projectID).First(&draft).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Draft not found"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"draft":   draft,
+		"expired": draft.ExpiredAt != nil,
+	})
+}
+
+// GetMyApplicationDrafts lists all of the student's drafts
+func GetMyApplicationDrafts(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can view application drafts"})
+	}
+
+	var drafts []models.ApplicationDraft
+	if err := config.DB.Where("uid = ?", userData.GetUID()).Order("updated_at DESC").Find(&drafts).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch drafts"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"drafts": drafts,
+		"count":  len(drafts),
+	})
+}
+
+// DeleteApplicationDraft discards the student's draft application for a project
+func DeleteApplicationDraft(c echo.Context) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can delete application drafts"})
+	}
+
+	result := config.DB.Unscoped().Where("uid = ? AND p_id = ?", userData.GetUID(), projectID).Delete(&models.ApplicationDraft{})
+	if result.Error != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to delete draft"})
+	}
+	if result.RowsAffected == 0 {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Draft not found"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "Draft deleted successfully"})
+}
+
+// SubmitApplicationDraft turns the student's draft into a real application
+func SubmitApplicationDraft(c echo.Context) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can submit application drafts"})
+	}
+
+	var draft models.ApplicationDraft
+	if err := config.DB.Where("uid = ? AND p_id = ?", userData.GetUID(), projectID).First(&draft).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Draft not found"})
+	}
+
+	if draft.ExpiredAt != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "This draft expired because the project is no longer accepting applications"})
+	}
+
+	input := applicationInput{
+		Availability:     draft.Availability,
+		Motivation:       draft.Motivation,
+		PriorProjects:    draft.PriorProjects,
+		CVLink:           draft.CVLink,
+		PublicationsLink: draft.PublicationsLink,
+	}
+
+	return submitApplication(c, projectID, userData, input, &draft)
+}
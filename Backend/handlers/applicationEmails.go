@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"backend/models"
+	"backend/utils"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Application emails are rendered from the templates in utils/templates/email in the recipient's locale.
+// Each builder returns a message addressed to toEmail, with HTML and plain-text bodies.
+
+// buildStatusUpdateEmail builds the email sent to a student when their application status changes
+func buildStatusUpdateEmail(toEmail, locale, studentName, projectName, status string) (*utils.EmailMessage, error) {
+	return utils.NewTemplateEmail(toEmail, "status_update", locale, echo.Map{
+		"StudentName": studentName,
+		"ProjectName": projectName,
+		"Status":      status,
+		"StatusLabel": formatApplicationStatus(status),
+	})
+}
+
+// buildFeedbackEmail builds the email carrying a professor's feedback to a student
+func buildFeedbackEmail(toEmail, locale, studentName, professorName, projectName, feedback string) (*utils.EmailMessage, error) {
+	return utils.NewTemplateEmail(toEmail, "feedback", locale, echo.Map{
+		"StudentName":   studentName,
+		"ProfessorName": professorName,
+		"ProjectName":   projectName,
+		"Feedback":      feedback,
+	})
+}
+
+// buildInterviewEmail builds the email sent to a student when an interview is scheduled
+func buildInterviewEmail(toEmail, locale, studentName, professorName, projectName, interviewDate, interviewTime, interviewDetails string) (*utils.EmailMessage, error) {
+	return utils.NewTemplateEmail(toEmail, "interview_scheduled", locale, echo.Map{
+		"StudentName":   studentName,
+		"ProfessorName": professorName,
+		"ProjectName":   projectName,
+		"Date":          interviewDate,
+		"Time":          interviewTime,
+		"Details":       interviewDetails,
+	})
+}
+
+// buildThreadReplyEmail builds the email sent to a professor when a student replies to feedback
+func buildThreadReplyEmail(toEmail, locale, professorName, studentName, projectName, reply string) (*utils.EmailMessage, error) {
+	return utils.NewTemplateEmail(toEmail, "thread_reply", locale, echo.Map{
+		"ProfessorName": professorName,
+		"StudentName":   studentName,
+		"ProjectName":   projectName,
+		"Reply":         reply,
+	})
+}
+
+// buildInterviewInviteEmail builds the email inviting a student to book an interview slot
+func buildInterviewInviteEmail(toEmail, locale, studentName, professorName, projectName, interviewDetails string) (*utils.EmailMessage, error) {
+	return utils.NewTemplateEmail(toEmail, "interview_invite", locale, echo.Map{
+		"StudentName":   studentName,
+		"ProfessorName": professorName,
+		"ProjectName":   projectName,
+		"Details":       interviewDetails,
+	})
+}
+
+// buildInterviewBookingEmail builds the email telling a professor that a student booked, rescheduled or
+// cancelled an interview slot
+func buildInterviewBookingEmail(toEmail, locale, professorName, studentName, projectName, action, slot string) (*utils.EmailMessage, error) {
+	return utils.NewTemplateEmail(toEmail, "interview_booking", locale, echo.Map{
+		"ProfessorName": professorName,
+		"StudentName":   studentName,
+		"ProjectName":   projectName,
+		"Action":        action,
+		"Slot":          slot,
+	})
+}
+
+// buildRecommendationRequestEmail builds the email asking a faculty member for a recommendation letter;
+// reminder switches the wording for follow-ups
+func buildRecommendationRequestEmail(toEmail, locale, refereeName, studentName, projectName, note, uploadURL string, reminder bool) (*utils.EmailMessage, error) {
+	return utils.NewTemplateEmail(toEmail, "recommendation_request", locale, echo.Map{
+		"RefereeName": refereeName,
+		"StudentName": studentName,
+		"ProjectName": projectName,
+		"Note":        note,
+		"UploadURL":   uploadURL,
+		"Reminder":    reminder,
+	})
+}
+
+// buildRecommendationResponseEmail builds the email telling a student that a referee submitted or declined
+// their recommendation request
+func buildRecommendationResponseEmail(toEmail, locale, studentName, refereeName, projectName, status string) (*utils.EmailMessage, error) {
+	return utils.NewTemplateEmail(toEmail, "recommendation_response", locale, echo.Map{
+		"StudentName": studentName,
+		"RefereeName": refereeName,
+		"ProjectName": projectName,
+		"Status":      status,
+	})
+}
+
+// digestEmailEntry is one line of a digest email
+type digestEmailEntry struct {
+	Summary string
+	Time    string
+}
+
+// buildNotificationDigestEmail builds a daily or weekly digest listing the notifications held back for a user,
+// with times shown in loc
+func buildNotificationDigestEmail(toEmail, locale, name, delivery string, loc *time.Location, entries []models.NotificationDigestEntry) (*utils.EmailMessage, error) {
+	lines := make([]digestEmailEntry, 0, len(entries))
+	for _, entry := range entries {
+		summary := entry.Summary
+		if summary == "" {
+			summary = entry.Subject
+		}
+		lines = append(lines, digestEmailEntry{Summary: summary, Time: entry.CreatedAt.In(loc).Format("Jan 2, 15:04 MST")})
+	}
+
+	return utils.NewTemplateEmail(toEmail, "notification_digest", locale, echo.Map{
+		"Name":    name,
+		"Weekly":  delivery == models.DeliveryWeekly,
+		"Entries": lines,
+	})
+}
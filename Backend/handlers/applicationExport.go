@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// applicationFilters are the optional status and date filters shared by application listings and exports
+type applicationFilters struct {
+	Statuses []string
+	From     *time.Time
+	To       *time.Time // Exclusive upper bound
+}
+
+// parseApplicationFilters reads ?status=a,b&from=YYYY-MM-DD&to=YYYY-MM-DD from the request
+func parseApplicationFilters(c echo.Context) (applicationFilters, error) {
+	var filters applicationFilters
+
+	if raw := c.QueryParam("status"); raw != "" {
+		for _, status := range strings.Split(raw, ",") {
+			status = strings.TrimSpace(status)
+			if !isValidApplicationStatus(status) {
+				return filters, fmt.Errorf("invalid status filter: %s", status)
+			}
+			filters.Statuses = append(filters.Statuses, status)
+		}
+	}
+
+	if raw := c.QueryParam("from"); raw != "" {
+		from, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return filters, fmt.Errorf("from must be a YYYY-MM-DD date")
+		}
+		filters.From = &from
+	}
+
+	if raw := c.QueryParam("to"); raw != "" {
+		to, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return filters, fmt.Errorf("to must be a YYYY-MM-DD date")
+		}
+		to = to.AddDate(0, 0, 1) // Include the whole end day
+		filters.To = &to
+	}
+
+	return filters, nil
+}
+
+// apply narrows an application query to the filters
+func (f applicationFilters) apply(query *gorm.DB) *gorm.DB {
+	if len(f.Statuses) > 0 {
+		query = query.Where("status IN ?", f.Statuses)
+	}
+	if f.From != nil {
+		query = query.Where("time_created >= ?", *f.From)
+	}
+	if f.To != nil {
+		query = query.Where("time_created < ?", *f.To)
+	}
+	return query
+}
+
+// applicationExportHeader lists the columns of an applicant export
+var applicationExportHeader = []string{
+	"Project ID", "Project", "Application ID", "Applied At", "Status",
+	"Applicant", "Email", "Institution", "Degree", "Location", "Dates",
+	"Skills", "Experience", "Projects", "Activities", "Research Interest", "Intention", "Resume",
+	"Availability", "Motivation", "Prior Projects", "CV Link", "Publications Link",
+	"Interview Date", "Interview Time", "Tags",
+}
+
+// buildApplicationExportRows flattens applications with their user and student profile into export rows
+func buildApplicationExportRows(projects map[string]models.Projects, applications []models.ProjRequests) [][]string {
+	uids := make([]string, 0, len(applications))
+	applicationIDs := make([]uint, 0, len(applications))
+	for _, app := range applications {
+		uids = append(uids, app.UID)
+		applicationIDs = append(applicationIDs, app.ID)
+	}
+
+	// Load users and student profiles in bulk
+	users := make(map[string]models.User, len(uids))
+	students := make(map[string]models.Students, len(uids))
+	if len(uids) > 0 {
+		var userRows []models.User
+		config.DB.Where("uid IN ?", uids).Find(&userRows)
+		for _, user := range userRows {
+			users[user.Uid] = user
+		}
+
+		var studentRows []models.Students
+		config.DB.Where("uid IN ?", uids).Find(&studentRows)
+		for _, student := range studentRows {
+			students[student.Uid] = student
+		}
+	}
+	tags := loadApplicationTags(applicationIDs)
+
+	rows := make([][]string, 0, len(applications))
+	for _, app := range applications {
+		project := projects[app.PID]
+		user := users[app.UID]
+		student := students[app.UID]
+
+		// Redact identifying fields while the project is in blind review
+		if isApplicantHidden(project, app.Status) {
+			redactApplicantForBlindReview(&app, &user, &student)
+		}
+
+		rows = append(rows, []string{
+			project.ProjectID, project.Name, strconv.FormatUint(uint64(app.ID), 10), app.TimeCreated.Format(time.RFC3339), app.Status,
+			user.Name, user.Email, student.Institution, student.Degree, student.Location, student.Dates,
+			strings.Join(student.Skills, "; "), student.Experience, strings.Join(student.Projects, "; "), strings.Join(student.Activities, "; "),
+			student.ResearchInterest, student.Intention, student.Resume,
+			app.Availability, app.Motivation, app.PriorProjects, app.CVLink, app.PublicationsLink,
+			app.InterviewDate, app.InterviewTime, strings.Join(tags[app.ID], "; "),
+		})
+	}
+	return rows
+}
+
+// writeApplicationExport streams rows to the client as CSV or XLSX
+func writeApplicationExport(c echo.Context, format, filename string, rows [][]string) error {
+	return writeSpreadsheet(c, format, filename, "Applications", applicationExportHeader, rows)
+}
+
+// writeSpreadsheet streams a header and rows to the client as a CSV or single-sheet XLSX download
+func writeSpreadsheet(c echo.Context, format, filename, sheetName string, header []string, rows [][]string) error {
+	res := c.Response()
+
+	switch format {
+	case "xlsx":
+		res.Header().Set(echo.HeaderContentType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.xlsx"`, filename))
+		res.WriteHeader(http.StatusOK)
+
+		sheet, err := utils.NewXLSXWriter(res, sheetName)
+		if err != nil {
+			return err
+		}
+		if err := sheet.WriteRow(header); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := sheet.WriteRow(row); err != nil {
+				return err
+			}
+		}
+		return sheet.Close()
+
+	default:
+		res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+		res.WriteHeader(http.StatusOK)
+
+		// Byte order mark so spreadsheet apps detect UTF-8
+		res.Write([]byte("\xEF\xBB\xBF"))
+
+		writer := csv.NewWriter(res)
+		writer.Write(csvSafeRow(header))
+		for i, row := range rows {
+			writer.Write(csvSafeRow(row))
+			if i%100 == 99 {
+				writer.Flush()
+				res.Flush()
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+}
+
+// csvSafeRow neutralises cells that spreadsheet apps would run as formulas (CSV injection) by prefixing
+// them with an apostrophe. XLSX cells are written as inline strings and are never evaluated.
+func csvSafeRow(row []string) []string {
+	safe := make([]string, len(row))
+	for i, cell := range row {
+		if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			cell = "'" + cell
+		}
+		safe[i] = cell
+	}
+	return safe
+}
+
+// parseExportFormat validates the ?format= parameter, defaulting to CSV
+func parseExportFormat(c echo.Context) (string, bool) {
+	format := strings.ToLower(c.QueryParam("format"))
+	if format == "" {
+		format = "csv"
+	}
+	return format, format == "csv" || format == "xlsx"
+}
+
+// applyExportReviewFilters honours the private ?tag= and ?search= review filters of the listing
+func applyExportReviewFilters(c echo.Context, applications []models.ProjRequests) []models.ProjRequests {
+	tag, search := c.QueryParam("tag"), c.QueryParam("search")
+	if tag == "" && search == "" {
+		return applications
+	}
+
+	applicationIDs := make([]uint, 0, len(applications))
+	for _, app := range applications {
+		applicationIDs = append(applicationIDs, app.ID)
+	}
+	matched := matchReviewFilters(applicationIDs, tag, search)
+
+	filtered := applications[:0]
+	for _, app := range applications {
+		if matched[app.ID] {
+			filtered = append(filtered, app)
+		}
+	}
+	return filtered
+}
+
+// ExportProjectApplications exports the applications of a project as CSV or XLSX
+func ExportProjectApplications(c echo.Context) error {
+	projectID := c.Param("id")
+	if projectID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Project ID is required"})
+	}
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a faculty member
+	if userData.GetUserType() != models.UserTypeFaculty {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can export project applications"})
+	}
+
+	format, ok := parseExportFormat(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Format must be csv or xlsx"})
+	}
+
+	filters, err := parseApplicationFilters(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	project, err := findReviewableProject(projectID, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission to view applications"})
+	}
+
+	var applications []models.ProjRequests
+	if err := filters.apply(config.DB.Where("p_id = ?", projectID)).Order("time_created ASC").Find(&applications).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch applications"})
+	}
+	applications = applyExportReviewFilters(c, applications)
+
+	rows := buildApplicationExportRows(map[string]models.Projects{project.ProjectID: project}, applications)
+	filename := fmt.Sprintf("applications-%s-%s", project.ProjectID, time.Now().Format("20060102"))
+	return writeApplicationExport(c, format, filename, rows)
+}
+
+// ExportAllMyProjectApplications exports the applications of every project the professor owns or reviews
+func ExportAllMyProjectApplications(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a faculty member
+	if userData.GetUserType() != models.UserTypeFaculty {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can export project applications"})
+	}
+
+	format, ok := parseExportFormat(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Format must be csv or xlsx"})
+	}
+
+	filters, err := parseApplicationFilters(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	var projectList []models.Projects
+	if err := config.DB.Where("creator_id = ? OR ? = ANY(reviewers)", userData.GetUID(), userData.GetUID()).Find(&projectList).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch projects"})
+	}
+
+	projects := make(map[string]models.Projects, len(projectList))
+	projectIDs := make([]string, 0, len(projectList))
+	for _, project := range projectList {
+		projects[project.ProjectID] = project
+		projectIDs = append(projectIDs, project.ProjectID)
+	}
+
+	var applications []models.ProjRequests
+	if len(projectIDs) > 0 {
+		query := config.DB.Where("p_id IN ?", projectIDs)
+		// Same default as the listing: decided applications are left out unless asked for
+		if len(filters.Statuses) == 0 {
+			query = query.Where("status NOT IN (?, ?)", "accepted", "rejected")
+		}
+		if err := filters.apply(query).Order("p_id ASC, time_created ASC").Find(&applications).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch applications"})
+		}
+	}
+
+	applications = applyExportReviewFilters(c, applications)
+
+	rows := buildApplicationExportRows(projects, applications)
+	filename := fmt.Sprintf("applications-%s", time.Now().Format("20060102"))
+	return writeApplicationExport(c, format, filename, rows)
+}
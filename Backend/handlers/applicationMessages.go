@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+const applicationMessageMaxLength = 5000 // Characters per thread message
+
+// loadApplicationThreads fetches the feedback threads of several applications with a single query
+func loadApplicationThreads(applicationIDs []uint) map[uint][]models.ApplicationMessage {
+	threads := make(map[uint][]models.ApplicationMessage, len(applicationIDs))
+	if len(applicationIDs) == 0 {
+		return threads
+	}
+
+	var messages []models.ApplicationMessage
+	if err := config.DB.Where("application_id IN ?", applicationIDs).Order("created_at ASC").Find(&messages).Error; err != nil {
+		log.Printf("Failed to load application threads: %v", err)
+		return threads
+	}
+
+	for _, msg := range messages {
+		threads[msg.ApplicationID] = append(threads[msg.ApplicationID], msg)
+	}
+	return threads
+}
+
+// countUnreadMessages counts the messages of a thread sent by the other side that the viewer has not read yet
+func countUnreadMessages(thread []models.ApplicationMessage, viewerType models.UserType) int {
+	unread := 0
+	for _, msg := range thread {
+		if msg.SenderType != viewerType && msg.ReadAt == nil {
+			unread++
+		}
+	}
+	return unread
+}
+
+// hideThreadSenders removes the student's ID from a thread shown to faculty during blind review
+func hideThreadSenders(thread []models.ApplicationMessage) []models.ApplicationMessage {
+	hidden := make([]models.ApplicationMessage, len(thread))
+	for i, msg := range thread {
+		if msg.SenderType == models.UserTypeStudent {
+			msg.SenderUID = ""
+		}
+		hidden[i] = msg
+	}
+	return hidden
+}
+
+// markThreadRead records a read receipt on every message of the thread sent by the other side
+func markThreadRead(applicationID uint, viewerType models.UserType) error {
+	return config.DB.Model(&models.ApplicationMessage{}).
+		Where("application_id = ? AND sender_type != ? AND read_at IS NULL", applicationID, viewerType).
+		Update("read_at", time.Now()).Error
+}
+
+// GetApplicationThread returns the feedback thread of an application to the owning professor
+func GetApplicationThread(c echo.Context) error {
+	projectID := c.Param("id")
+	applicationID := c.Param("appId")
+
+	if projectID == "" || applicationID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Project ID and Application ID are required"})
+	}
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a faculty member
+	if userData.GetUserType() != models.UserTypeFaculty {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can view this thread"})
+	}
+
+	// Check if the professor owns or reviews the project
+	project, err := findReviewableProject(projectID, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	var application models.ProjRequests
+	if err := config.DB.Where("id = ? AND p_id = ?", applicationID, projectID).First(&application).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Application not found"})
+	}
+
+	if err := markThreadRead(application.ID, models.UserTypeFaculty); err != nil {
+		log.Printf("Failed to mark thread %d as read: %v", application.ID, err)
+	}
+
+	thread := loadApplicationThreads([]uint{application.ID})[application.ID]
+	if isApplicantHidden(project, application.Status) {
+		thread = hideThreadSenders(thread)
+	}
+	if thread == nil {
+		thread = []models.ApplicationMessage{}
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"applicationId": application.ID,
+		"messages":      thread,
+		"count":         len(thread),
+	})
+}
+
+// GetMyApplicationThread returns the feedback thread of one of the student's own applications
+func GetMyApplicationThread(c echo.Context) error {
+	applicationID := c.Param("appId")
+	if applicationID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Application ID is required"})
+	}
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can view their application threads"})
+	}
+
+	var application models.ProjRequests
+	if err := config.DB.Where("id = ? AND uid = ?", applicationID, userData.GetUID()).First(&application).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Application not found"})
+	}
+
+	if err := markThreadRead(application.ID, models.UserTypeStudent); err != nil {
+		log.Printf("Failed to mark thread %d as read: %v", application.ID, err)
+	}
+
+	thread := loadApplicationThreads([]uint{application.ID})[application.ID]
+	if thread == nil {
+		thread = []models.ApplicationMessage{}
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"applicationId": application.ID,
+		"messages":      thread,
+		"count":         len(thread),
+	})
+}
+
+// ReplyToApplicationThread lets a student reply to the feedback thread of their application
+func ReplyToApplicationThread(c echo.Context) error {
+	applicationID := c.Param("appId")
+	if applicationID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Application ID is required"})
+	}
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can reply to their application threads"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Body string `json:"body"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if strings.TrimSpace(requestBody.Body) == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Message body is required"})
+	}
+	if len([]rune(requestBody.Body)) > applicationMessageMaxLength {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Message cannot be longer than %d characters", applicationMessageMaxLength)})
+	}
+
+	var application models.ProjRequests
+	if err := config.DB.Where("id = ? AND uid = ?", applicationID, userData.GetUID()).First(&application).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Application not found"})
+	}
+
+	var project models.Projects
+	if err := config.DB.Where("project_id = ?", application.PID).First(&project).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	}
+
+	// Students may only reply once faculty has started the thread
+	var facultyMessages int64
+	if err := config.DB.Model(&models.ApplicationMessage{}).
+		Where("application_id = ? AND sender_type = ?", application.ID, models.UserTypeFaculty).
+		Count(&facultyMessages).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to load application thread"})
+	}
+	if facultyMessages == 0 {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "You can only reply once the professor has sent feedback"})
+	}
+
+	message := models.ApplicationMessage{
+		ApplicationID: application.ID,
+		PID:           application.PID,
+		SenderUID:     userData.GetUID(),
+		SenderType:    models.UserTypeStudent,
+		Body:          requestBody.Body,
+	}
+
+	if err := config.DB.Create(&message).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save reply"})
+	}
+
+	// Notify the professor about the reply
+	go func() {
+		var professor models.User
+		if err := config.DB.Where("uid = ?", project.CreatorID).First(&professor).Error; err != nil {
+			log.Printf("Failed to fetch professor for reply email: %v", err)
+			return
+		}
+
+		var student models.User
+		if err := config.DB.Where("uid = ?", application.UID).First(&student).Error; err != nil {
+			log.Printf("Failed to fetch student for reply email: %v", err)
+			return
+		}
+
+		// Keep the applicant anonymous while the project is in blind review
+		studentName := student.Name
+		if isApplicantHidden(project, application.Status) {
+			studentName = utils.GenerateApplicantHandle(project.ProjectID, student.Uid)
+		}
+
+		createNotifications([]models.Notification{applicationNotification(professor.Uid, models.NotificationFeedbackReceived,
+			fmt.Sprintf("New reply on %s", project.Name),
+			fmt.Sprintf("%s replied to your feedback on their application for %s.", studentName, project.Name),
+			application)})
+
+		emailMessage, err := buildThreadReplyEmail(professor.Email, loadEmailLocale(professor.Uid), professor.Name, studentName, project.Name, requestBody.Body)
+		if err != nil {
+			log.Printf("Failed to build reply email to professor %s: %v", professor.Email, err)
+			return
+		}
+
+		dispatchEmail(models.NotificationFeedbackReceived, professor.Uid, emailMessage,
+			fmt.Sprintf("%s replied to your feedback on their application for %s.", studentName, project.Name))
+	}()
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Reply sent successfully",
+		"reply":   message,
+	})
+}
+
+// saveFeedbackMessages stores professor feedback as thread entries for the given applications
+func saveFeedbackMessages(db *gorm.DB, professorUID string, applications []models.ProjRequests, bodies []string) error {
+	if len(applications) == 0 {
+		return nil
+	}
+
+	messages := make([]models.ApplicationMessage, 0, len(applications))
+	for i, app := range applications {
+		messages = append(messages, models.ApplicationMessage{
+			ApplicationID: app.ID,
+			PID:           app.PID,
+			SenderUID:     professorUID,
+			SenderType:    models.UserTypeFaculty,
+			Body:          bodies[i],
+		})
+	}
+
+	return db.Create(&messages).Error
+}
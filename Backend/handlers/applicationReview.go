@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm/clause"
+)
+
+const maxTagLength = 50
+
+// normalizeTagLabel trims and lowercases a tag so labels group consistently
+func normalizeTagLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
+
+// loadApplicationNotes fetches the private notes of several applications with a single query
+func loadApplicationNotes(applicationIDs []uint) map[uint][]models.ApplicationNote {
+	notes := make(map[uint][]models.ApplicationNote, len(applicationIDs))
+	if len(applicationIDs) == 0 {
+		return notes
+	}
+
+	var rows []models.ApplicationNote
+	if err := config.DB.Where("application_id IN ?", applicationIDs).Order("created_at ASC").Find(&rows).Error; err != nil {
+		log.Printf("Failed to load application notes: %v", err)
+		return notes
+	}
+
+	for _, note := range rows {
+		notes[note.ApplicationID] = append(notes[note.ApplicationID], note)
+	}
+	return notes
+}
+
+// loadApplicationTags fetches the tag labels of several applications with a single query
+func loadApplicationTags(applicationIDs []uint) map[uint][]string {
+	tags := make(map[uint][]string, len(applicationIDs))
+	if len(applicationIDs) == 0 {
+		return tags
+	}
+
+	var rows []models.ApplicationTag
+	if err := config.DB.Where("application_id IN ?", applicationIDs).Order("label ASC").Find(&rows).Error; err != nil {
+		log.Printf("Failed to load application tags: %v", err)
+		return tags
+	}
+
+	for _, tag := range rows {
+		tags[tag.ApplicationID] = append(tags[tag.ApplicationID], tag.Label)
+	}
+	return tags
+}
+
+// likeEscaper escapes the wildcards of user input so it matches literally inside an ILIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// matchReviewFilters returns the applications that carry the given tag and whose notes or tags contain the search text
+func matchReviewFilters(applicationIDs []uint, tag, search string) map[uint]bool {
+	matched := make(map[uint]bool, len(applicationIDs))
+	for _, id := range applicationIDs {
+		matched[id] = true
+	}
+	if len(applicationIDs) == 0 {
+		return matched
+	}
+
+	if label := normalizeTagLabel(tag); label != "" {
+		var tagged []uint
+		config.DB.Model(&models.ApplicationTag{}).
+			Where("application_id IN ? AND label = ?", applicationIDs, label).
+			Pluck("application_id", &tagged)
+		matched = intersectApplicationIDs(matched, tagged)
+	}
+
+	if search = strings.TrimSpace(search); search != "" {
+		pattern := "%" + likeEscaper.Replace(search) + "%"
+		var found []uint
+		config.DB.Model(&models.ApplicationNote{}).
+			Where("application_id IN ? AND body ILIKE ? ESCAPE '\\'", applicationIDs, pattern).
+			Pluck("application_id", &found)
+
+		var foundByTag []uint
+		config.DB.Model(&models.ApplicationTag{}).
+			Where("application_id IN ? AND label ILIKE ? ESCAPE '\\'", applicationIDs, pattern).
+			Pluck("application_id", &foundByTag)
+
+		matched = intersectApplicationIDs(matched, append(found, foundByTag...))
+	}
+
+	return matched
+}
+
+// intersectApplicationIDs keeps only the IDs of the set that also appear in the list
+func intersectApplicationIDs(set map[uint]bool, ids []uint) map[uint]bool {
+	result := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if set[id] {
+			result[id] = true
+		}
+	}
+	return result
+}
+
+// findReviewableApplication loads an application of a project the user owns or reviews
+func findReviewableApplication(c echo.Context) (models.ProjRequests, error) {
+	projectID := c.Param("id")
+	applicationID := c.Param("appId")
+
+	if projectID == "" || applicationID == "" {
+		return models.ProjRequests{}, echo.NewHTTPError(http.StatusBadRequest, "Project ID and Application ID are required")
+	}
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a faculty member
+	if userData.GetUserType() != models.UserTypeFaculty {
+		return models.ProjRequests{}, echo.NewHTTPError(http.StatusForbidden, "Only faculty can review applications")
+	}
+
+	if _, err := findReviewableProject(projectID, userData.GetUID()); err != nil {
+		return models.ProjRequests{}, echo.NewHTTPError(http.StatusNotFound, "Project not found or you don't have permission")
+	}
+
+	var application models.ProjRequests
+	if err := config.DB.Where("id = ? AND p_id = ?", applicationID, projectID).First(&application).Error; err != nil {
+		return models.ProjRequests{}, echo.NewHTTPError(http.StatusNotFound, "Application not found")
+	}
+
+	return application, nil
+}
+
+// reviewError converts an error from findReviewableApplication into the repo's JSON error shape
+func reviewError(c echo.Context, err error) error {
+	if httpErr, ok := err.(*echo.HTTPError); ok {
+		return c.JSON(httpErr.Code, echo.Map{"error": httpErr.Message})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to load application"})
+}
+
+// GetApplicationReview returns the private notes and tags of an application to its owners and reviewers
+func GetApplicationReview(c echo.Context) error {
+	application, err := findReviewableApplication(c)
+	if err != nil {
+		return reviewError(c, err)
+	}
+
+	notes := loadApplicationNotes([]uint{application.ID})[application.ID]
+	if notes == nil {
+		notes = []models.ApplicationNote{}
+	}
+	tags := loadApplicationTags([]uint{application.ID})[application.ID]
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"applicationId": application.ID,
+		"notes":         notes,
+		"tags":          tags,
+	})
+}
+
+// AddApplicationNote adds a private note to an application
+func AddApplicationNote(c echo.Context) error {
+	application, err := findReviewableApplication(c)
+	if err != nil {
+		return reviewError(c, err)
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Body string `json:"body"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if strings.TrimSpace(requestBody.Body) == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Note body is required"})
+	}
+
+	userData := c.Get("userData").(models.UserData)
+	note := models.ApplicationNote{
+		ApplicationID: application.ID,
+		PID:           application.PID,
+		AuthorUID:     userData.GetUID(),
+		Body:          requestBody.Body,
+	}
+
+	if err := config.DB.Create(&note).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save note"})
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Note added successfully",
+		"note":    note,
+	})
+}
+
+// DeleteApplicationNote removes a private note; only its author can delete it
+func DeleteApplicationNote(c echo.Context) error {
+	application, err := findReviewableApplication(c)
+	if err != nil {
+		return reviewError(c, err)
+	}
+
+	userData := c.Get("userData").(models.UserData)
+	result := config.DB.Where("id = ? AND application_id = ? AND author_uid = ?", c.Param("noteId"), application.ID, userData.GetUID()).
+		Delete(&models.ApplicationNote{})
+	if result.Error != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to delete note"})
+	}
+	if result.RowsAffected == 0 {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Note not found or you are not its author"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "Note deleted successfully"})
+}
+
+// AddApplicationTag attaches a custom label to an application
+func AddApplicationTag(c echo.Context) error {
+	application, err := findReviewableApplication(c)
+	if err != nil {
+		return reviewError(c, err)
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Label string `json:"label"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	label := normalizeTagLabel(requestBody.Label)
+	if label == "" || len(label) > maxTagLength {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Tag label must be between 1 and 50 characters"})
+	}
+
+	userData := c.Get("userData").(models.UserData)
+	tag := models.ApplicationTag{
+		ApplicationID: application.ID,
+		PID:           application.PID,
+		Label:         label,
+		CreatedBy:     userData.GetUID(),
+	}
+
+	// Adding a tag that is already present is a no-op
+	if err := config.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&tag).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to add tag"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Tag added successfully",
+		"tags":    loadApplicationTags([]uint{application.ID})[application.ID],
+	})
+}
+
+// RemoveApplicationTag removes a custom label from an application
+func RemoveApplicationTag(c echo.Context) error {
+	application, err := findReviewableApplication(c)
+	if err != nil {
+		return reviewError(c, err)
+	}
+
+	rawLabel, err := url.PathUnescape(c.Param("label"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid tag label"})
+	}
+
+	label := normalizeTagLabel(rawLabel)
+	if err := config.DB.Where("application_id = ? AND label = ?", application.ID, label).Delete(&models.ApplicationTag{}).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to remove tag"})
+	}
+
+	tags := loadApplicationTags([]uint{application.ID})[application.ID]
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Tag removed successfully",
+		"tags":    tags,
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"backend/config"
 	"backend/models"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
@@ -26,13 +27,19 @@ func GetMyApplicationForProject(c echo.Context) error {
 
 	// Define a lightweight response structure with only needed fields
 	type ApplicationStatusResponse struct {
-		ID               uint   `json:"ID"`
-		Status           string `json:"status"`
-		TimeCreated      string `json:"time_created"`
-		InterviewDate    string `json:"interviewDate,omitempty"`
-		InterviewTime    string `json:"interviewTime,omitempty"`
-		InterviewDetails string `json:"interviewDetails,omitempty"`
-		HasApplied       bool   `json:"hasApplied"`
+		ID                uint                        `json:"ID"`
+		Status            string                      `json:"status"`
+		TimeCreated       string                      `json:"time_created"`
+		InterviewDate     string                      `json:"interviewDate,omitempty"`
+		InterviewTime     string                      `json:"interviewTime,omitempty"`
+		InterviewDetails  string                      `json:"interviewDetails,omitempty"`
+		InterviewStartsAt *time.Time                  `json:"interviewStartsAt,omitempty"`
+		InterviewEndsAt   *time.Time                  `json:"interviewEndsAt,omitempty"`
+		InterviewTimezone string                      `json:"interviewTimezone,omitempty"`
+		HasApplied        bool                        `json:"hasApplied"`
+		Messages          []models.ApplicationMessage `json:"messages"`
+		UnreadMessages    int                         `json:"unreadMessages"`
+		Recommendations   []recommendationStatus      `json:"recommendations"`
 	}
 
 	// Single optimized query - only fetch the specific application
@@ -52,15 +59,24 @@ func GetMyApplicationForProject(c echo.Context) error {
 
 	// Application found - return the status
 	response := ApplicationStatusResponse{
-		ID:               application.ID,
-		Status:           application.Status,
-		TimeCreated:      application.TimeCreated.Format("2006-01-02T15:04:05Z07:00"),
-		InterviewDate:    application.InterviewDate,
-		InterviewTime:    application.InterviewTime,
-		InterviewDetails: application.InterviewDetails,
-		HasApplied:       true,
+		ID:                application.ID,
+		Status:            application.Status,
+		TimeCreated:       application.TimeCreated.Format("2006-01-02T15:04:05Z07:00"),
+		InterviewDate:     application.InterviewDate,
+		InterviewTime:     application.InterviewTime,
+		InterviewDetails:  application.InterviewDetails,
+		InterviewStartsAt: application.InterviewStartsAt,
+		InterviewEndsAt:   application.InterviewEndsAt,
+		InterviewTimezone: application.InterviewTimezone,
+		HasApplied:        true,
 	}
 
+	// Attach the feedback thread
+	thread := loadApplicationThreads([]uint{application.ID})[application.ID]
+	response.Messages = thread
+	response.UnreadMessages = countUnreadMessages(thread, models.UserTypeStudent)
+	response.Recommendations = loadRecommendationStatuses([]uint{application.ID})[application.ID]
+
 	return c.JSON(http.StatusOK, echo.Map{
 		"hasApplied":  true,
 		"application": response,
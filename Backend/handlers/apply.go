@@ -12,6 +12,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ApplyToProject handles student applications to projects
@@ -30,18 +31,25 @@ func ApplyToProject(c echo.Context) error {
 	}
 
 	// Parse request body for application details
-	var applicationRequest struct {
-		Availability     string `json:"availability"`
-		Motivation       string `json:"motivation"`
-		PriorProjects    string `json:"priorProjects"`
-		CVLink           string `json:"cvLink"`
-		PublicationsLink string `json:"publicationsLink"`
-	}
-
+	var applicationRequest applicationInput
 	if err := c.Bind(&applicationRequest); err != nil {
 		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid request body"})
 	}
 
+	return submitApplication(c, projectID, userData, applicationRequest, nil)
+}
+
+// applicationInput holds the answers a student submits with an application
+type applicationInput struct {
+	Availability     string `json:"availability"`
+	Motivation       string `json:"motivation"`
+	PriorProjects    string `json:"priorProjects"`
+	CVLink           string `json:"cvLink"`
+	PublicationsLink string `json:"publicationsLink"`
+}
+
+// submitApplication validates and creates an application; a draft, when given, is removed in the same transaction
+func submitApplication(c echo.Context, projectID string, userData models.UserData, applicationRequest applicationInput, draft *models.ApplicationDraft) error {
 	tx := config.DB.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -56,6 +64,21 @@ func ApplyToProject(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or not active"})
 	}
 
+	// Check the project's eligibility rules
+	if reasons := evaluateEligibility(project, loadEligibilityProfile(tx, userData.GetUID())); len(reasons) > 0 {
+		tx.Rollback()
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"error":   "You are not eligible for this project: " + strings.Join(reasons, "; "),
+			"reasons": reasons,
+		})
+	}
+
+	// Lock the student row so concurrent applications cannot exceed the limits
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uid = ?", userData.GetUID()).First(&models.User{}).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Student not found"})
+	}
+
 	// Check if student has already applied to this project (excluding soft-deleted/rejected applications)
 	var existingApplication models.ProjRequests
 	result := tx.Unscoped().Where("uid = ? AND p_id = ?", userData.GetUID(), projectID).First(&existingApplication)
@@ -72,6 +95,21 @@ func ApplyToProject(c echo.Context) error {
 		}
 	}
 
+	// Enforce the per-student application limits
+	quota, err := loadApplicationQuota(tx, userData.GetUID(), utils.LoadApplicationLimits())
+	if err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to check application limits"})
+	}
+	if quota.Remaining != nil && *quota.Remaining == 0 {
+		tx.Rollback()
+		return c.JSON(http.StatusTooManyRequests, echo.Map{
+			"error":          quota.limitMessage(),
+			"remainingSlots": 0,
+			"limits":         quota,
+		})
+	}
+
 	// Create new application with additional fields
 	application := models.ProjRequests{
 		TimeCreated:      time.Now(),
@@ -94,10 +132,20 @@ func ApplyToProject(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to submit application"})
 	}
 
+	// The draft becomes the submitted application
+	if draft != nil {
+		if err := tx.Unscoped().Delete(draft).Error; err != nil {
+			tx.Rollback()
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to submit draft"})
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save application"})
 	}
 
+	emitWebhookEvent(models.WebhookApplicationCreated, project, []string{application.UID}, webhookApplicationData(application, project))
+
 	// Send email notification to the professor
 	go func() {
 		// Fetch professor details
@@ -114,16 +162,38 @@ func ApplyToProject(c echo.Context) error {
 			return
 		}
 
-		// Send email to professor
-		emailConfig := utils.LoadEmailConfig()
-		if err := utils.SendProjectApplicationEmail(emailConfig, professor.Email, project.Name, student.Name); err != nil {
-			log.Printf("Failed to send application email to professor %s: %v", professor.Email, err)
+		// Keep the applicant anonymous in the email while the project is in blind review
+		applicantName := student.Name
+		if isApplicantHidden(project, application.Status) {
+			applicantName = utils.GenerateApplicantHandle(project.ProjectID, student.Uid)
 		}
+
+		createNotifications([]models.Notification{applicationNotification(professor.Uid, models.NotificationApplicationSubmitted,
+			fmt.Sprintf("New application for %s", project.Name),
+			fmt.Sprintf("%s applied to %s.", applicantName, project.Name),
+			application)})
+
+		// Email the professor now, or hold it for their digest
+		emailMessage, err := utils.BuildProjectApplicationEmail(professor.Email, loadEmailLocale(professor.Uid), project.Name, applicantName)
+		if err != nil {
+			log.Printf("Failed to build application email to professor %s: %v", professor.Email, err)
+			return
+		}
+		dispatchEmail(models.NotificationApplicationSubmitted, professor.Uid, emailMessage,
+			fmt.Sprintf("%s applied to %s.", applicantName, project.Name))
 	}()
 
+	// Report the slots left after this application
+	var remainingSlots *int
+	if quota.Remaining != nil {
+		remaining := *quota.Remaining - 1
+		remainingSlots = &remaining
+	}
+
 	return c.JSON(http.StatusCreated, echo.Map{
-		"message":     "Application submitted successfully",
-		"application": application,
+		"message":        "Application submitted successfully",
+		"application":    application,
+		"remainingSlots": remainingSlots,
 	})
 }
 
@@ -142,9 +212,14 @@ func GetProjectApplications(c echo.Context) error {
 		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can view project applications"})
 	}
 
-	// Check if project exists and belongs to the professor (by creator)
-	var project models.Projects
-	if err := config.DB.Where("project_id = ? AND creator_id = ?", projectID, userData.GetUID()).First(&project).Error; err != nil {
+	filters, err := parseApplicationFilters(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	// Check if project exists and the professor owns or reviews it
+	project, err := findReviewableProject(projectID, userData.GetUID())
+	if err != nil {
 		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission to view applications"})
 	}
 
@@ -171,16 +246,40 @@ func GetProjectApplications(c echo.Context) error {
 		Resume           string   `json:"resumeLink"`
 
 		// Interview fields
-		InterviewDate    string `json:"interviewDate"`
-		InterviewTime    string `json:"interviewTime"`
-		InterviewDetails string `json:"interviewDetails"`
+		InterviewDate     string     `json:"interviewDate"`
+		InterviewTime     string     `json:"interviewTime"`
+		InterviewDetails  string     `json:"interviewDetails"`
+		InterviewStartsAt *time.Time `json:"interviewStartsAt"`
+		InterviewEndsAt   *time.Time `json:"interviewEndsAt"`
+		InterviewTimezone string     `json:"interviewTimezone"`
+
+		// Blind review fields
+		ApplicantHandle string `json:"applicantHandle"`
+		IdentityHidden  bool   `json:"identityHidden"`
+
+		// Feedback thread
+		Messages       []models.ApplicationMessage `json:"messages"`
+		UnreadMessages int                         `json:"unreadMessages"`
+
+		// Private review fields
+		Notes []models.ApplicationNote `json:"notes"`
+		Tags  []string                 `json:"tags"`
 	}
 
 	var applications []models.ProjRequests
-	if err := config.DB.Where("p_id = ?", projectID).Find(&applications).Error; err != nil {
+	if err := filters.apply(config.DB.Where("p_id = ?", projectID)).Find(&applications).Error; err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch applications"})
 	}
 
+	// Load every feedback thread in a single query
+	applicationIDs := make([]uint, 0, len(applications))
+	for _, app := range applications {
+		applicationIDs = append(applicationIDs, app.ID)
+	}
+	threads := loadApplicationThreads(applicationIDs)
+	notes := loadApplicationNotes(applicationIDs)
+	tags := loadApplicationTags(applicationIDs)
+
 	var flattenedApplications []FlattenedApplication
 
 	// For each application, fetch user and student details
@@ -196,6 +295,12 @@ func GetProjectApplications(c echo.Context) error {
 			student = models.Students{Uid: app.UID}
 		}
 
+		// Redact identifying fields while the project is in blind review
+		hidden := isApplicantHidden(project, app.Status)
+		if hidden {
+			redactApplicantForBlindReview(&app, &user, &student)
+		}
+
 		flattenedApp := FlattenedApplication{
 			// Application fields
 			ID:          app.ID,
@@ -218,9 +323,29 @@ func GetProjectApplications(c echo.Context) error {
 			Resume:           student.Resume,
 
 			// Interview fields
-			InterviewDate:    app.InterviewDate,
-			InterviewTime:    app.InterviewTime,
-			InterviewDetails: app.InterviewDetails,
+			InterviewDate:     app.InterviewDate,
+			InterviewTime:     app.InterviewTime,
+			InterviewDetails:  app.InterviewDetails,
+			InterviewStartsAt: app.InterviewStartsAt,
+			InterviewEndsAt:   app.InterviewEndsAt,
+			InterviewTimezone: app.InterviewTimezone,
+
+			// Blind review fields
+			ApplicantHandle: utils.GenerateApplicantHandle(app.PID, app.UID),
+
+			// Feedback thread
+			Messages:       threads[app.ID],
+			UnreadMessages: countUnreadMessages(threads[app.ID], models.UserTypeFaculty),
+
+			// Private review fields
+			Notes: notes[app.ID],
+			Tags:  tags[app.ID],
+		}
+
+		if hidden {
+			flattenedApp.Messages = hideThreadSenders(flattenedApp.Messages)
+			flattenedApp.IdentityHidden = true
+			flattenedApp.UID = ""
 		}
 		flattenedApplications = append(flattenedApplications, flattenedApp)
 	}
@@ -259,15 +384,7 @@ func UpdateApplicationStatus(c echo.Context) error {
 	}
 
 	// Validate status
-	validStatuses := []string{"accepted", "rejected", "waitlisted", "interview", "under_review", "approved"}
-	isValidStatus := false
-	for _, validStatus := range validStatuses {
-		if requestBody.Status == validStatus {
-			isValidStatus = true
-			break
-		}
-	}
-	if !isValidStatus {
+	if !isValidApplicationStatus(requestBody.Status) {
 		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid status. Must be one of: accepted, rejected, waitlisted, interview, under_review, approved"})
 	}
 
@@ -292,28 +409,20 @@ func UpdateApplicationStatus(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, echo.Map{"error": "Application not found"})
 	}
 
-	// Update the status
-	if err := tx.Model(&application).Update("status", requestBody.Status).Error; err != nil {
+	// Update the status; leaving the interview stage frees any upcoming booked slot
+	previousStatus := application.Status
+	updates := applicationStatusUpdates(requestBody.Status)
+	released := releaseInterviewForStatus(application, requestBody.Status, updates)
+	if err := tx.Model(&application).Updates(updates).Error; err != nil {
 		tx.Rollback()
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update application status"})
 	}
 
 	// If accepted, add user to project's working users
 	if requestBody.Status == "accepted" {
-		// Use a database-level check to prevent race conditions
-		var count int64
-		tx.Raw("SELECT COUNT(*) FROM projects WHERE project_id = ? AND ? = ANY(working_users)",
-			projectID, application.UID).Scan(&count)
-
-		if count == 0 {
-			// Use PostgreSQL array append function to safely add user
-			if err := tx.Exec(
-				"UPDATE projects SET working_users = array_append(working_users, ?), updated_at = ? WHERE project_id = ?",
-				application.UID, time.Now(), projectID,
-			).Error; err != nil {
-				tx.Rollback()
-				return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to add user to project"})
-			}
+		if err := addWorkingUser(tx, projectID, application.UID); err != nil {
+			tx.Rollback()
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to add user to project"})
 		}
 	}
 
@@ -321,6 +430,12 @@ func UpdateApplicationStatus(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
 	}
 
+	createNotifications([]models.Notification{statusChangeNotification(application, project.Name, requestBody.Status)})
+
+	if previousStatus != requestBody.Status {
+		emitApplicationStatusChanged(application, project, previousStatus, requestBody.Status)
+	}
+
 	// Send email notification to the student about status update
 	go func() {
 		// Fetch student details
@@ -331,167 +446,23 @@ func UpdateApplicationStatus(c echo.Context) error {
 		}
 
 		// Send appropriate email based on status
-		emailConfig := utils.LoadEmailConfig()
-		var emailBody string
-		var subject string
-
-		switch requestBody.Status {
-		case "accepted", "approved":
-			subject = fmt.Sprintf("Congratulations! Application Accepted for %s", project.Name)
-			emailBody = fmt.Sprintf(`
-				<html>
-				<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-					<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-						<!-- Header -->
-						<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-							<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-						</div>
-						
-						<!-- Content -->
-						<div style="padding: 40px 20px;">
-							<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">Application Accepted!</h2>
-							<p style="margin: 0 0 16px 0; color: #000;">Hi %s,</p>
-							<p style="margin: 0 0 16px 0; color: #000;">Great news! Your application for <strong>%s</strong> has been accepted.</p>
-							<p style="margin: 0 0 16px 0; color: #000;">The project lead will contact you shortly with next steps.</p>
-							<p style="margin: 0; color: #000;">Log in to your dashboard to view more details.</p>
-						</div>
-						
-						<!-- Footer -->
-						<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-							<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-							<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-						</div>
-					</div>
-				</body>
-				</html>
-			`, student.Name, project.Name)
-		case "rejected":
-			subject = fmt.Sprintf("Application Update for %s", project.Name)
-			emailBody = fmt.Sprintf(`
-				<html>
-				<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-					<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-						<!-- Header -->
-						<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-							<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-						</div>
-						
-						<!-- Content -->
-						<div style="padding: 40px 20px;">
-							<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">Application Update</h2>
-							<p style="margin: 0 0 16px 0; color: #000;">Hi %s,</p>
-							<p style="margin: 0 0 16px 0; color: #000;">Thank you for your interest in <strong>%s</strong>.</p>
-							<p style="margin: 0 0 16px 0; color: #000;">Unfortunately, we are unable to move forward with your application at this time.</p>
-							<p style="margin: 0; color: #000;">We encourage you to explore other exciting projects on our platform.</p>
-						</div>
-						
-						<!-- Footer -->
-						<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-							<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-							<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-						</div>
-					</div>
-				</body>
-				</html>
-			`, student.Name, project.Name)
-		case "interview":
-			subject = fmt.Sprintf("Interview Request for %s", project.Name)
-			emailBody = fmt.Sprintf(`
-				<html>
-				<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-					<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-						<!-- Header -->
-						<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-							<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-						</div>
-						
-						<!-- Content -->
-						<div style="padding: 40px 20px;">
-							<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">Interview Request</h2>
-							<p style="margin: 0 0 16px 0; color: #000;">Hi %s,</p>
-							<p style="margin: 0 0 16px 0; color: #000;">Your application for <strong>%s</strong> has been reviewed and the project lead would like to interview you.</p>
-							<p style="margin: 0; color: #000;">Please check your dashboard for more details and contact information.</p>
-						</div>
-						
-						<!-- Footer -->
-						<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-							<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-							<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-						</div>
-					</div>
-				</body>
-				</html>
-			`, student.Name, project.Name)
-		case "waitlisted":
-			subject = fmt.Sprintf("Application Waitlisted for %s", project.Name)
-			emailBody = fmt.Sprintf(`
-				<html>
-				<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-					<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-						<!-- Header -->
-						<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-							<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-						</div>
-						
-						<!-- Content -->
-						<div style="padding: 40px 20px;">
-							<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">Application Waitlisted</h2>
-							<p style="margin: 0 0 16px 0; color: #000;">Hi %s,</p>
-							<p style="margin: 0 0 16px 0; color: #000;">Your application for <strong>%s</strong> has been placed on the waitlist.</p>
-							<p style="margin: 0 0 16px 0; color: #000;">We'll notify you if a position becomes available.</p>
-							<p style="margin: 0; color: #000;">Thank you for your patience!</p>
-						</div>
-						
-						<!-- Footer -->
-						<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-							<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-							<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-						</div>
-					</div>
-				</body>
-				</html>
-			`, student.Name, project.Name)
-		default:
-			// For other statuses, send a generic update
-			subject = fmt.Sprintf("Application Status Update for %s", project.Name)
-			emailBody = fmt.Sprintf(`
-				<html>
-				<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-					<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-						<!-- Header -->
-						<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-							<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-						</div>
-						
-						<!-- Content -->
-						<div style="padding: 40px 20px;">
-							<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">Application Status Update</h2>
-							<p style="margin: 0 0 16px 0; color: #000;">Hi %s,</p>
-							<p style="margin: 0 0 16px 0; color: #000;">Your application status for <strong>%s</strong> has been updated to: <strong>%s</strong></p>
-							<p style="margin: 0; color: #000;">Log in to your dashboard to view more details.</p>
-						</div>
-						
-						<!-- Footer -->
-						<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-							<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-							<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-						</div>
-					</div>
-				</body>
-				</html>
-			`, student.Name, project.Name, requestBody.Status)
+		emailMessage, err := buildStatusUpdateEmail(student.Email, loadEmailLocale(student.Uid), student.Name, project.Name, requestBody.Status)
+		if err != nil {
+			log.Printf("Failed to build status update email to student %s: %v", student.Email, err)
+			return
 		}
 
-		emailMessage := &utils.EmailMessage{
-			To:      []string{student.Email},
-			Subject: subject,
-			Body:    emailBody,
-			IsHTML:  true,
+		// Withdraw the calendar invite of the released slot
+		if released != nil {
+			var professor models.User
+			if err := config.DB.Where("uid = ?", project.CreatorID).First(&professor).Error; err != nil {
+				log.Printf("Failed to fetch professor for interview cancellation: %v", err)
+			}
+			emailMessage.Attachments = []utils.EmailAttachment{interviewCancellationInvite(*released, project.Name, professor.Email, student.Email)}
 		}
 
-		if err := utils.SendEmail(emailConfig, emailMessage); err != nil {
-			log.Printf("Failed to send status update email to student %s: %v", student.Email, err)
-		}
+		dispatchEmail(models.NotificationStatusChanged, student.Uid, emailMessage,
+			fmt.Sprintf("Your application for %s is now %s.", project.Name, formatApplicationStatus(requestBody.Status)))
 	}()
 
 	// Fetch updated application
@@ -499,12 +470,62 @@ func UpdateApplicationStatus(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch updated application"})
 	}
 
+	// Do not leak the applicant's ID while the project is in blind review
+	if isApplicantHidden(project, application.Status) {
+		application.UID = ""
+	}
+
 	return c.JSON(http.StatusOK, echo.Map{
 		"message":     "Application status updated successfully",
 		"application": application,
 	})
 }
 
+// validApplicationStatuses lists every status an application can be moved to
+var validApplicationStatuses = []string{"accepted", "rejected", "waitlisted", "interview", "under_review", "approved"}
+
+// isValidApplicationStatus checks a status against validApplicationStatuses
+func isValidApplicationStatus(status string) bool {
+	for _, validStatus := range validApplicationStatuses {
+		if status == validStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// isApplicationDecided reports whether a status is final for the applicant
+func isApplicationDecided(status string) bool {
+	return status == "accepted" || status == "approved" || status == "rejected"
+}
+
+// applicationStatusUpdates returns the column updates for moving an application to a status, keeping decided_at in step
+func applicationStatusUpdates(status string) map[string]interface{} {
+	updates := map[string]interface{}{"status": status, "decided_at": nil}
+	if isApplicationDecided(status) {
+		updates["decided_at"] = time.Now()
+	}
+	return updates
+}
+
+// addWorkingUser adds a student to the project's working users if they are not already a member
+func addWorkingUser(tx *gorm.DB, projectID, uid string) error {
+	// Use a database-level check to prevent race conditions
+	var count int64
+	tx.Raw("SELECT COUNT(*) FROM projects WHERE project_id = ? AND ? = ANY(working_users)",
+		projectID, uid).Scan(&count)
+
+	if count > 0 {
+		return nil
+	}
+
+	// Use PostgreSQL array append function to safely add user
+	return tx.Exec(
+		"UPDATE projects SET working_users = array_append(working_users, ?), updated_at = ? WHERE project_id = ?",
+		uid, time.Now(), projectID,
+	).Error
+}
+
 // GetMyApplications returns all applications made by the authenticated student
 func GetMyApplications(c echo.Context) error {
 	// Get user data from context
@@ -517,22 +538,28 @@ func GetMyApplications(c echo.Context) error {
 
 	// Define a struct to hold application with project details
 	type ApplicationResponse struct {
-		ID               uint      `json:"ID"`
-		CreatedAt        time.Time `json:"CreatedAt"`
-		UpdatedAt        time.Time `json:"UpdatedAt"`
-		TimeCreated      time.Time `json:"time_created"`
-		Status           string    `json:"status"`
-		UID              string    `json:"uid"`
-		PID              string    `json:"pid"`
-		Availability     string    `json:"availability"`
-		Motivation       string    `json:"motivation"`
-		PriorProjects    string    `json:"priorProjects"`
-		CVLink           string    `json:"cvLink"`
-		PublicationsLink string    `json:"publicationsLink"`
-		InterviewDate    string    `json:"interviewDate"`
-		InterviewTime    string    `json:"interviewTime"`
-		InterviewDetails string    `json:"interviewDetails"`
-		Project          struct {
+		ID                uint                        `json:"ID"`
+		CreatedAt         time.Time                   `json:"CreatedAt"`
+		UpdatedAt         time.Time                   `json:"UpdatedAt"`
+		TimeCreated       time.Time                   `json:"time_created"`
+		Status            string                      `json:"status"`
+		UID               string                      `json:"uid"`
+		PID               string                      `json:"pid"`
+		Availability      string                      `json:"availability"`
+		Motivation        string                      `json:"motivation"`
+		PriorProjects     string                      `json:"priorProjects"`
+		CVLink            string                      `json:"cvLink"`
+		PublicationsLink  string                      `json:"publicationsLink"`
+		InterviewDate     string                      `json:"interviewDate"`
+		InterviewTime     string                      `json:"interviewTime"`
+		InterviewDetails  string                      `json:"interviewDetails"`
+		InterviewStartsAt *time.Time                  `json:"interviewStartsAt"`
+		InterviewEndsAt   *time.Time                  `json:"interviewEndsAt"`
+		InterviewTimezone string                      `json:"interviewTimezone"`
+		Messages          []models.ApplicationMessage `json:"messages"`
+		UnreadMessages    int                         `json:"unreadMessages"`
+		Recommendations   []recommendationStatus      `json:"recommendations"`
+		Project           struct {
 			ID           uint      `json:"ID"`
 			CreatedAt    time.Time `json:"CreatedAt"`
 			UpdatedAt    time.Time `json:"UpdatedAt"`
@@ -562,6 +589,14 @@ func GetMyApplications(c echo.Context) error {
 	}
 	var applicationsResponse []ApplicationResponse
 
+	// Load every feedback thread in a single query
+	applicationIDs := make([]uint, 0, len(applications))
+	for _, app := range applications {
+		applicationIDs = append(applicationIDs, app.ID)
+	}
+	threads := loadApplicationThreads(applicationIDs)
+	recommendations := loadRecommendationStatuses(applicationIDs)
+
 	// For each application, fetch project and professor details
 	for _, app := range applications {
 		var project models.Projects
@@ -577,21 +612,27 @@ func GetMyApplications(c echo.Context) error {
 		}
 
 		appResponse := ApplicationResponse{
-			ID:               app.ID,
-			CreatedAt:        app.CreatedAt,
-			UpdatedAt:        app.UpdatedAt,
-			TimeCreated:      app.TimeCreated,
-			Status:           app.Status,
-			UID:              app.UID,
-			PID:              app.PID,
-			Availability:     app.Availability,
-			Motivation:       app.Motivation,
-			PriorProjects:    app.PriorProjects,
-			CVLink:           app.CVLink,
-			PublicationsLink: app.PublicationsLink,
-			InterviewDate:    app.InterviewDate,
-			InterviewTime:    app.InterviewTime,
-			InterviewDetails: app.InterviewDetails,
+			ID:                app.ID,
+			CreatedAt:         app.CreatedAt,
+			UpdatedAt:         app.UpdatedAt,
+			TimeCreated:       app.TimeCreated,
+			Status:            app.Status,
+			UID:               app.UID,
+			PID:               app.PID,
+			Availability:      app.Availability,
+			Motivation:        app.Motivation,
+			PriorProjects:     app.PriorProjects,
+			CVLink:            app.CVLink,
+			PublicationsLink:  app.PublicationsLink,
+			InterviewDate:     app.InterviewDate,
+			InterviewTime:     app.InterviewTime,
+			InterviewDetails:  app.InterviewDetails,
+			InterviewStartsAt: app.InterviewStartsAt,
+			InterviewEndsAt:   app.InterviewEndsAt,
+			InterviewTimezone: app.InterviewTimezone,
+			Messages:          threads[app.ID],
+			UnreadMessages:    countUnreadMessages(threads[app.ID], models.UserTypeStudent),
+			Recommendations:   recommendations[app.ID],
 		}
 
 		appResponse.Project.ID = project.ID
@@ -633,9 +674,17 @@ func GetAllMyProjectApplications(c echo.Context) error {
 		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can view project applications"})
 	}
 
-	// Get all projects created by this professor
+	// Optional filters on status, date and private review data
+	filters, err := parseApplicationFilters(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+	tagFilter := c.QueryParam("tag")
+	searchFilter := c.QueryParam("search")
+
+	// Get all projects created or reviewed by this professor
 	var projects []models.Projects
-	if err := config.DB.Where("creator_id = ?", userData.GetUID()).Find(&projects).Error; err != nil {
+	if err := config.DB.Where("creator_id = ? OR ? = ANY(reviewers)", userData.GetUID(), userData.GetUID()).Find(&projects).Error; err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch projects"})
 	}
 
@@ -654,9 +703,12 @@ func GetAllMyProjectApplications(c echo.Context) error {
 		PublicationsLink string    `json:"publicationsLink"`
 
 		// Interview fields
-		InterviewDate    string `json:"interviewDate"`
-		InterviewTime    string `json:"interviewTime"`
-		InterviewDetails string `json:"interviewDetails"`
+		InterviewDate     string     `json:"interviewDate"`
+		InterviewTime     string     `json:"interviewTime"`
+		InterviewDetails  string     `json:"interviewDetails"`
+		InterviewStartsAt *time.Time `json:"interviewStartsAt"`
+		InterviewEndsAt   *time.Time `json:"interviewEndsAt"`
+		InterviewTimezone string     `json:"interviewTimezone"`
 
 		// Student/User fields
 		Name     string `json:"name"`
@@ -676,6 +728,18 @@ func GetAllMyProjectApplications(c echo.Context) error {
 		Resume           string   `json:"resumeLink"`
 		ResearchInterest string   `json:"researchInterest"`
 		Intention        string   `json:"intention"`
+
+		// Blind review fields
+		ApplicantHandle string `json:"applicantHandle"`
+		IdentityHidden  bool   `json:"identityHidden"`
+
+		// Feedback thread
+		Messages       []models.ApplicationMessage `json:"messages"`
+		UnreadMessages int                         `json:"unreadMessages"`
+
+		// Private review fields
+		Notes []models.ApplicationNote `json:"notes"`
+		Tags  []string                 `json:"tags"`
 	}
 
 	type ProjectWithApplications struct {
@@ -686,17 +750,36 @@ func GetAllMyProjectApplications(c echo.Context) error {
 
 	var result []ProjectWithApplications
 
-	// For each project, fetch all applications excluding accepted/rejected ones
+	// For each project, fetch all applications excluding accepted/rejected ones unless a status filter is given
 	for _, project := range projects {
+		query := config.DB.Where("p_id = ?", project.ProjectID)
+		if len(filters.Statuses) == 0 {
+			query = query.Where("status NOT IN (?, ?)", "accepted", "rejected")
+		}
+
 		var applications []models.ProjRequests
-		if err := config.DB.Where("p_id = ? AND status NOT IN (?, ?)", project.ProjectID, "accepted", "rejected").Find(&applications).Error; err != nil {
+		if err := filters.apply(query).Find(&applications).Error; err != nil {
 			continue // Skip if error fetching applications
 		}
 
+		// Load every feedback thread of this project in a single query
+		applicationIDs := make([]uint, 0, len(applications))
+		for _, app := range applications {
+			applicationIDs = append(applicationIDs, app.ID)
+		}
+		threads := loadApplicationThreads(applicationIDs)
+		notes := loadApplicationNotes(applicationIDs)
+		tags := loadApplicationTags(applicationIDs)
+		matched := matchReviewFilters(applicationIDs, tagFilter, searchFilter)
+
 		var detailedApplications []ApplicationWithDetails
 
 		// For each application, fetch user and student details
 		for _, app := range applications {
+			if !matched[app.ID] {
+				continue // Skip applications filtered out by tag or search
+			}
+
 			var user models.User
 			if err := config.DB.Where("uid = ?", app.UID).First(&user).Error; err != nil {
 				continue // Skip if user not found
@@ -708,6 +791,12 @@ func GetAllMyProjectApplications(c echo.Context) error {
 				student = models.Students{Uid: app.UID}
 			}
 
+			// Redact identifying fields while the project is in blind review
+			hidden := isApplicantHidden(project, app.Status)
+			if hidden {
+				redactApplicantForBlindReview(&app, &user, &student)
+			}
+
 			detailedApp := ApplicationWithDetails{
 				// Application fields
 				ID:               app.ID,
@@ -722,9 +811,12 @@ func GetAllMyProjectApplications(c echo.Context) error {
 				PublicationsLink: app.PublicationsLink,
 
 				// Interview fields
-				InterviewDate:    app.InterviewDate,
-				InterviewTime:    app.InterviewTime,
-				InterviewDetails: app.InterviewDetails,
+				InterviewDate:     app.InterviewDate,
+				InterviewTime:     app.InterviewTime,
+				InterviewDetails:  app.InterviewDetails,
+				InterviewStartsAt: app.InterviewStartsAt,
+				InterviewEndsAt:   app.InterviewEndsAt,
+				InterviewTimezone: app.InterviewTimezone,
 
 				// User fields
 				Name:     user.Name,
@@ -744,10 +836,33 @@ func GetAllMyProjectApplications(c echo.Context) error {
 				Resume:           student.Resume,
 				ResearchInterest: student.ResearchInterest,
 				Intention:        student.Intention,
+
+				// Blind review fields
+				ApplicantHandle: utils.GenerateApplicantHandle(app.PID, app.UID),
+
+				// Feedback thread
+				Messages:       threads[app.ID],
+				UnreadMessages: countUnreadMessages(threads[app.ID], models.UserTypeFaculty),
+
+				// Private review fields
+				Notes: notes[app.ID],
+				Tags:  tags[app.ID],
+			}
+
+			if hidden {
+				detailedApp.Messages = hideThreadSenders(detailedApp.Messages)
+				detailedApp.IdentityHidden = true
+				detailedApp.UID = ""
 			}
 			detailedApplications = append(detailedApplications, detailedApp)
 		}
 
+		// Hide projects with no matching applications while filtering
+		filtering := tagFilter != "" || searchFilter != "" || len(filters.Statuses) > 0 || filters.From != nil || filters.To != nil
+		if filtering && len(detailedApplications) == 0 {
+			continue
+		}
+
 		projectWithApps := ProjectWithApplications{
 			Project:      project,
 			Applications: detailedApplications,
@@ -816,53 +931,36 @@ func SendApplicationFeedback(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Professor not found"})
 	}
 
-	// Send feedback email to the student
-	emailConfig := utils.LoadEmailConfig()
-	subject := fmt.Sprintf("Feedback on your application for %s", project.Name)
-	emailBody := fmt.Sprintf(`
-		<html>
-		<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-			<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-				<!-- Header -->
-				<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-					<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-				</div>
-				
-				<!-- Content -->
-				<div style="padding: 40px 20px;">
-					<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">Application Feedback</h2>
-					<p style="margin: 0 0 16px 0; color: #000;">Hi %s,</p>
-					<p style="margin: 0 0 24px 0; color: #000;">You have received feedback from <strong>%s</strong> regarding your application for <strong>%s</strong>:</p>
-					<div style="background-color: #f5f5f5; padding: 20px; border-left: 4px solid #000; margin: 24px 0;">
-						<p style="margin: 0; white-space: pre-wrap; color: #000;">%s</p>
-					</div>
-					<p style="margin: 0; color: #000;">Log in to your dashboard to view your application status and more details.</p>
-				</div>
-				
-				<!-- Footer -->
-				<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-					<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-					<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-				</div>
-			</div>
-		</body>
-		</html>
-	`, student.Name, professor.Name, project.Name, requestBody.Feedback)
-
-	emailMessage := &utils.EmailMessage{
-		To:      []string{student.Email},
-		Subject: subject,
-		Body:    emailBody,
-		IsHTML:  true,
-	}
-
-	if err := utils.SendEmail(emailConfig, emailMessage); err != nil {
-		log.Printf("Failed to send feedback email to student %s: %v", student.Email, err)
-		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to send feedback email"})
+	// Store the feedback in the application's thread
+	feedback := models.ApplicationMessage{
+		ApplicationID: application.ID,
+		PID:           application.PID,
+		SenderUID:     userData.GetUID(),
+		SenderType:    models.UserTypeFaculty,
+		Body:          requestBody.Feedback,
 	}
 
-	return c.JSON(http.StatusOK, echo.Map{
-		"message": "Feedback sent successfully",
+	if err := config.DB.Create(&feedback).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save feedback"})
+	}
+
+	createNotifications([]models.Notification{feedbackNotification(application, project.Name)})
+
+	// Send feedback email to the student
+	go func() {
+		emailMessage, err := buildFeedbackEmail(student.Email, loadEmailLocale(student.Uid), student.Name, professor.Name, project.Name, requestBody.Feedback)
+		if err != nil {
+			log.Printf("Failed to build feedback email to student %s: %v", student.Email, err)
+			return
+		}
+
+		dispatchEmail(models.NotificationFeedbackReceived, student.Uid, emailMessage,
+			fmt.Sprintf("%s left feedback on your application for %s.", professor.Name, project.Name))
+	}()
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message":  "Feedback sent successfully",
+		"feedback": feedback,
 	})
 }
 
@@ -888,13 +986,43 @@ func ScheduleInterview(c echo.Context) error {
 		InterviewDate    string `json:"interviewDate" binding:"required"`
 		InterviewTime    string `json:"interviewTime" binding:"required"`
 		InterviewDetails string `json:"interviewDetails"`
+		SelfBook         bool   `json:"selfBook"` // Let the student pick a slot from published availability
+
+		// Optional exact slot; when set a calendar invite is attached to the email
+		StartsAt        string `json:"startsAt"`
+		DurationMinutes int    `json:"durationMinutes"`
+		Timezone        string `json:"timezone"`
 	}
 
 	if err := c.Bind(&requestBody); err != nil {
 		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
 	}
 
-	if requestBody.InterviewDate == "" || requestBody.InterviewTime == "" {
+	var startsAt, endsAt *time.Time
+	var timezone string
+	if requestBody.StartsAt != "" && !requestBody.SelfBook {
+		loc, err := loadLocation(requestBody.Timezone)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid timezone"})
+		}
+		start, err := parseWindowTime(requestBody.StartsAt, loc)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid interview start time"})
+		}
+		if requestBody.DurationMinutes <= 0 {
+			requestBody.DurationMinutes = 30
+		}
+		end := start.Add(time.Duration(requestBody.DurationMinutes) * time.Minute)
+		start, end = start.UTC(), end.UTC()
+		startsAt, endsAt, timezone = &start, &end, loc.String()
+
+		// Keep the legacy strings filled for older clients
+		if requestBody.InterviewDate == "" || requestBody.InterviewTime == "" {
+			requestBody.InterviewDate, requestBody.InterviewTime = formatInterviewSlot(start, end, loc)
+		}
+	}
+
+	if !requestBody.SelfBook && (requestBody.InterviewDate == "" || requestBody.InterviewTime == "") {
 		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Interview date and time are required"})
 	}
 
@@ -919,12 +1047,48 @@ func ScheduleInterview(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, echo.Map{"error": "Application not found"})
 	}
 
+	// An exact slot must not collide with the professor's other interviews on any of their projects
+	if startsAt != nil {
+		// Lock the professor row so this and concurrent student bookings are serialized
+		var professor models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uid = ?", userData.GetUID()).First(&professor).Error; err != nil {
+			tx.Rollback()
+			return c.JSON(http.StatusNotFound, echo.Map{"error": "Professor not found"})
+		}
+
+		bookings, err := loadProfessorBookings(tx, userData.GetUID(), *startsAt, *endsAt, application.ID)
+		if err != nil {
+			tx.Rollback()
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to check availability"})
+		}
+		if len(bookings) > 0 {
+			tx.Rollback()
+			return c.JSON(http.StatusConflict, echo.Map{"error": "You already have another interview booked at this time"})
+		}
+	}
+
 	// Update the application with interview details and status
 	updates := map[string]interface{}{
-		"status":            "interview",
-		"interview_date":    requestBody.InterviewDate,
-		"interview_time":    requestBody.InterviewTime,
-		"interview_details": requestBody.InterviewDetails,
+		"status":              "interview",
+		"decided_at":          nil,
+		"interview_date":      requestBody.InterviewDate,
+		"interview_time":      requestBody.InterviewTime,
+		"interview_details":   requestBody.InterviewDetails,
+		"interview_starts_at": startsAt,
+		"interview_ends_at":   endsAt,
+	}
+	if startsAt != nil {
+		updates["interview_timezone"] = timezone
+		updates["interview_booked_at"] = time.Now()
+	}
+	if requestBody.SelfBook {
+		updates["interview_date"] = ""
+		updates["interview_time"] = ""
+	}
+	// Replacing a booked slot releases it
+	var released *models.ProjRequests
+	if startsAt == nil {
+		released = releaseInterviewSlot(application, updates)
 	}
 
 	if err := tx.Model(&application).Updates(updates).Error; err != nil {
@@ -936,6 +1100,8 @@ func ScheduleInterview(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
 	}
 
+	createNotifications([]models.Notification{interviewNotification(application, project.Name, requestBody.InterviewDate, requestBody.InterviewTime, requestBody.SelfBook)})
+
 	// Send interview email to the student
 	go func() {
 		var student models.User
@@ -950,57 +1116,33 @@ func ScheduleInterview(c echo.Context) error {
 			return
 		}
 
-		emailConfig := utils.LoadEmailConfig()
-		subject := fmt.Sprintf("Interview Scheduled for %s", project.Name)
-		emailBody := fmt.Sprintf(`
-			<html>
-			<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-				<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-					<!-- Header -->
-					<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-						<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-					</div>
-					
-					<!-- Content -->
-					<div style="padding: 40px 20px;">
-						<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">Interview Scheduled!</h2>
-						<p style="margin: 0 0 16px 0; color: #000;">Hi %s,</p>
-						<p style="margin: 0 0 24px 0; color: #000;"><strong>%s</strong> has scheduled an interview with you for the project: <strong>%s</strong></p>
-						<div style="background-color: #f5f5f5; padding: 20px; border: 1px solid #000; margin: 24px 0;">
-							<p style="margin: 0 0 12px 0; color: #000;"><strong>📅 Date:</strong> %s</p>
-							<p style="margin: 0 0 12px 0; color: #000;"><strong>🕐 Time:</strong> %s</p>
-							%s
-						</div>
-						<p style="margin: 0 0 16px 0; color: #000;">Please make sure to be available at the scheduled time. Good luck!</p>
-						<p style="margin: 0; color: #000;">Log in to your dashboard to view more details.</p>
-					</div>
-					
-					<!-- Footer -->
-					<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-						<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-						<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-					</div>
-				</div>
-			</body>
-			</html>
-		`, student.Name, professor.Name, project.Name, requestBody.InterviewDate, requestBody.InterviewTime,
-			func() string {
-				if requestBody.InterviewDetails != "" {
-					return fmt.Sprintf(`<p style="margin: 0; color: #000;"><strong>📝 Details:</strong> %s</p>`, requestBody.InterviewDetails)
-				}
-				return ""
-			}())
-
-		emailMessage := &utils.EmailMessage{
-			To:      []string{student.Email},
-			Subject: subject,
-			Body:    emailBody,
-			IsHTML:  true,
+		locale := loadEmailLocale(student.Uid)
+		var emailMessage *utils.EmailMessage
+		var err error
+		if requestBody.SelfBook {
+			emailMessage, err = buildInterviewInviteEmail(student.Email, locale, student.Name, professor.Name, project.Name, requestBody.InterviewDetails)
+		} else {
+			emailMessage, err = buildInterviewEmail(student.Email, locale, student.Name, professor.Name, project.Name, requestBody.InterviewDate, requestBody.InterviewTime, requestBody.InterviewDetails)
+		}
+		if err != nil {
+			log.Printf("Failed to build interview email to student %s: %v", student.Email, err)
+			return
 		}
 
-		if err := utils.SendEmail(emailConfig, emailMessage); err != nil {
-			log.Printf("Failed to send interview email to student %s: %v", student.Email, err)
+		// Attach a calendar invite when the interview has an exact slot
+		if startsAt != nil {
+			scheduled := application
+			scheduled.InterviewStartsAt, scheduled.InterviewEndsAt = startsAt, endsAt
+			scheduled.InterviewDetails = requestBody.InterviewDetails
+			emailMessage.Attachments = []utils.EmailAttachment{utils.CalendarAttachment(utils.CalendarMethodRequest, []utils.CalendarEvent{
+				interviewCalendarEvent(scheduled, project.Name, professor.Email, student.Email, false),
+			})}
+		} else if released != nil {
+			emailMessage.Attachments = []utils.EmailAttachment{interviewCancellationInvite(*released, project.Name, professor.Email, student.Email)}
 		}
+
+		notification := interviewNotification(application, project.Name, requestBody.InterviewDate, requestBody.InterviewTime, requestBody.SelfBook)
+		dispatchEmail(models.NotificationInterviewScheduled, student.Uid, emailMessage, notification.Body)
 	}()
 
 	// Fetch updated application
@@ -1044,6 +1186,13 @@ func GetPastApplicantsForProject(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch past applicants"})
 	}
 
+	// Load every feedback thread in a single query
+	applicationIDs := make([]uint, 0, len(applications))
+	for _, app := range applications {
+		applicationIDs = append(applicationIDs, app.ID)
+	}
+	threads := loadApplicationThreads(applicationIDs)
+
 	// Define a flattened struct for application details
 	type ApplicationWithDetails struct {
 		// Application fields
@@ -1059,9 +1208,12 @@ func GetPastApplicantsForProject(c echo.Context) error {
 		PublicationsLink string    `json:"publicationsLink"`
 
 		// Interview fields
-		InterviewDate    string `json:"interviewDate"`
-		InterviewTime    string `json:"interviewTime"`
-		InterviewDetails string `json:"interviewDetails"`
+		InterviewDate     string     `json:"interviewDate"`
+		InterviewTime     string     `json:"interviewTime"`
+		InterviewDetails  string     `json:"interviewDetails"`
+		InterviewStartsAt *time.Time `json:"interviewStartsAt"`
+		InterviewEndsAt   *time.Time `json:"interviewEndsAt"`
+		InterviewTimezone string     `json:"interviewTimezone"`
 
 		// Student/User fields
 		Name     string `json:"name"`
@@ -1081,6 +1233,14 @@ func GetPastApplicantsForProject(c echo.Context) error {
 		Resume           string   `json:"resumeLink"`
 		ResearchInterest string   `json:"researchInterest"`
 		Intention        string   `json:"intention"`
+
+		// Blind review fields
+		ApplicantHandle string `json:"applicantHandle"`
+		IdentityHidden  bool   `json:"identityHidden"`
+
+		// Feedback thread
+		Messages       []models.ApplicationMessage `json:"messages"`
+		UnreadMessages int                         `json:"unreadMessages"`
 	}
 
 	var detailedApplications []ApplicationWithDetails
@@ -1098,6 +1258,12 @@ func GetPastApplicantsForProject(c echo.Context) error {
 			student = models.Students{Uid: app.UID}
 		}
 
+		// Redact identifying fields while the project is in blind review
+		hidden := isApplicantHidden(project, app.Status)
+		if hidden {
+			redactApplicantForBlindReview(&app, &user, &student)
+		}
+
 		detailedApp := ApplicationWithDetails{
 			// Application fields
 			ID:               app.ID,
@@ -1112,9 +1278,12 @@ func GetPastApplicantsForProject(c echo.Context) error {
 			PublicationsLink: app.PublicationsLink,
 
 			// Interview fields
-			InterviewDate:    app.InterviewDate,
-			InterviewTime:    app.InterviewTime,
-			InterviewDetails: app.InterviewDetails,
+			InterviewDate:     app.InterviewDate,
+			InterviewTime:     app.InterviewTime,
+			InterviewDetails:  app.InterviewDetails,
+			InterviewStartsAt: app.InterviewStartsAt,
+			InterviewEndsAt:   app.InterviewEndsAt,
+			InterviewTimezone: app.InterviewTimezone,
 
 			// User fields
 			Name:     user.Name,
@@ -1134,6 +1303,19 @@ func GetPastApplicantsForProject(c echo.Context) error {
 			Resume:           student.Resume,
 			ResearchInterest: student.ResearchInterest,
 			Intention:        student.Intention,
+
+			// Blind review fields
+			ApplicantHandle: utils.GenerateApplicantHandle(app.PID, app.UID),
+
+			// Feedback thread
+			Messages:       threads[app.ID],
+			UnreadMessages: countUnreadMessages(threads[app.ID], models.UserTypeFaculty),
+		}
+
+		if hidden {
+			detailedApp.Messages = hideThreadSenders(detailedApp.Messages)
+			detailedApp.IdentityHidden = true
+			detailedApp.UID = ""
 		}
 		detailedApplications = append(detailedApplications, detailedApp)
 	}
@@ -1183,7 +1365,8 @@ func RetractApplication(c echo.Context) error {
 		})
 	}
 
-	// Delete the application
+	// Delete the application; an upcoming booked slot goes with it
+	releasesInterview := hasUpcomingInterview(application)
 	if err := tx.Delete(&application).Error; err != nil {
 		tx.Rollback()
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to retract application"})
@@ -1202,7 +1385,15 @@ func RetractApplication(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
 	}
 
-	return c.JSON(http.StatusOK, echo.Map{
-		"message": "Application retracted successfully",
-	})
+	if releasesInterview {
+		go sendInterviewCancellationToProfessor(application)
+	}
+
+	response := echo.Map{"message": "Application retracted successfully"}
+	if quota, err := loadApplicationQuota(config.DB, userData.GetUID(), utils.LoadApplicationLimits()); err == nil {
+		response["remainingSlots"] = quota.Remaining
+	} else {
+		log.Printf("Failed to load application limits after retraction: %v", err)
+	}
+	return c.JSON(http.StatusOK, response)
 }
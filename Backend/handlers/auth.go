@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"fmt"
 	"log"
 	"net/http"
 	"strings"
@@ -16,6 +15,11 @@ import (
 	"backend/utils"
 )
 
+// requestEmailLocale picks the language of an account email from the browser that asked for it
+func requestEmailLocale(c echo.Context) string {
+	return utils.NormalizeEmailLocale(c.Request().Header.Get("Accept-Language"))
+}
+
 // generateuid creates a unique 12-character uid
 
 func Signup(c echo.Context) error {
@@ -142,42 +146,12 @@ func Signup(c echo.Context) error {
 	}
 
 	// Send verification email asynchronously
-	go func(email, name, verificationCode string) {
+	go func(email, name, verificationCode, locale string) {
 		emailConfig := utils.LoadEmailConfig()
-		emailMessage := &utils.EmailMessage{
-			To:      []string{email},
-			Subject: "Verify Your Email Address",
-			Body: fmt.Sprintf(`
-				<html>
-				<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-					<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-						<!-- Header -->
-						<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-							<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-						</div>
-						
-						<!-- Content -->
-						<div style="padding: 40px 20px;">
-							<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">Welcome</h2>
-							<p style="margin: 0 0 16px 0; color: #000;">Hi %s,</p>
-							<p style="margin: 0 0 24px 0; color: #000;">Thank you for signing up. Please verify your email address using the code below:</p>
-							<div style="background-color: #f5f5f5; padding: 20px; text-align: center; font-size: 32px; font-weight: 600; letter-spacing: 8px; margin: 32px 0; border: 1px solid #000;">
-								%s
-							</div>
-							<p style="margin: 0 0 8px 0; color: #666; font-size: 14px;">This code will expire in 10 minutes.</p>
-							<p style="margin: 0; color: #666; font-size: 14px;">If you didn't create an account, please ignore this email.</p>
-						</div>
-						
-						<!-- Footer -->
-						<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-							<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-							<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-						</div>
-					</div>
-				</body>
-				</html>
-			`, name, verificationCode),
-			IsHTML: true,
+		emailMessage, err := utils.NewTemplateEmail(email, "verification_code", locale, echo.Map{"Name": name, "Code": verificationCode, "Welcome": true})
+		if err != nil {
+			log.Printf("Failed to build verification code email to %s: %v", email, err)
+			return
 		}
 
 		if err := utils.SendEmail(emailConfig, emailMessage); err != nil {
@@ -185,7 +159,7 @@ func Signup(c echo.Context) error {
 		} else {
 			log.Printf("Successfully sent verification email with code %s to %s", verificationCode, email)
 		}
-	}(user.Email, user.Name, code)
+	}(user.Email, user.Name, code, requestEmailLocale(c))
 
 	user.Password = "" // hide password in response
 	return c.JSON(http.StatusCreated, echo.Map{
@@ -341,12 +315,12 @@ func ForgotPassword(c echo.Context) error {
 	}
 
 	// Send password reset email asynchronously
-	go func(email, resetToken string) {
+	go func(email, resetToken, locale string) {
 		emailConfig := utils.LoadEmailConfig()
-		if err := utils.SendPasswordResetEmail(emailConfig, email, resetToken); err != nil {
+		if err := utils.SendPasswordResetEmail(emailConfig, email, locale, resetToken); err != nil {
 			log.Printf("Failed to send password reset email to %s: %v", email, err)
 		}
-	}(req.Email, token)
+	}(req.Email, token, requestEmailLocale(c))
 
 	return c.JSON(http.StatusOK, echo.Map{
 		"message": "If an account with that email exists, a password reset link will be sent shortly",
@@ -472,50 +446,18 @@ func ResetPassword(c echo.Context) error {
 	}
 
 	// Send password reset confirmation email asynchronously
-	go func(email, name string) {
+	go func(email, name, locale string) {
 		emailConfig := utils.LoadEmailConfig()
-		emailMessage := &utils.EmailMessage{
-			To:      []string{email},
-			Subject: "Password Reset Successful",
-			Body: fmt.Sprintf(`
-				<html>
-				<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-					<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-						<!-- Header -->
-						<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-							<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-						</div>
-						
-						<!-- Content -->
-						<div style="padding: 40px 20px;">
-							<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">Password Reset Successful</h2>
-							<p style="margin: 0 0 16px 0; color: #000;">Hi %s,</p>
-							<p style="margin: 0 0 24px 0; color: #000;">Your password has been successfully reset.</p>
-							<p style="margin: 0 0 16px 0; color: #000;">If you did not make this change, please contact our support team immediately.</p>
-							<p style="margin: 0 0 8px 0; color: #000; font-weight: 500;">For security, we recommend:</p>
-							<ul style="margin: 0 0 24px 0; padding-left: 20px; color: #000;">
-								<li style="margin-bottom: 8px;">Using a strong, unique password</li>
-								<li style="margin-bottom: 8px;">Enabling two-factor authentication if available</li>
-								<li style="margin-bottom: 0;">Not sharing your password with anyone</li>
-							</ul>
-						</div>
-						
-						<!-- Footer -->
-						<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-							<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-							<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-						</div>
-					</div>
-				</body>
-				</html>
-			`, name),
-			IsHTML: true,
+		emailMessage, err := utils.NewTemplateEmail(email, "password_reset_success", locale, echo.Map{"Name": name})
+		if err != nil {
+			log.Printf("Failed to build password reset confirmation email to %s: %v", email, err)
+			return
 		}
 
 		if err := utils.SendEmail(emailConfig, emailMessage); err != nil {
 			log.Printf("Failed to send password reset confirmation email to %s: %v", email, err)
 		}
-	}(user.Email, user.Name)
+	}(user.Email, user.Name, requestEmailLocale(c))
 
 	return c.JSON(http.StatusOK, echo.Map{
 		"message": "Password has been reset successfully. A confirmation email will be sent shortly.",
@@ -587,48 +529,18 @@ func SendVerificationCode(c echo.Context) error {
 	}
 
 	// Send verification email asynchronously
-	go func(email, name, verificationCode string) {
+	go func(email, name, verificationCode, locale string) {
 		emailConfig := utils.LoadEmailConfig()
-		emailMessage := &utils.EmailMessage{
-			To:      []string{email},
-			Subject: "Your Verification Code",
-			Body: fmt.Sprintf(`
-				<html>
-				<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-					<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-						<!-- Header -->
-						<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-							<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-						</div>
-						
-						<!-- Content -->
-						<div style="padding: 40px 20px;">
-							<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">Email Verification</h2>
-							<p style="margin: 0 0 16px 0; color: #000;">Hi %s,</p>
-							<p style="margin: 0 0 24px 0; color: #000;">Your verification code is:</p>
-							<div style="background-color: #f5f5f5; padding: 20px; text-align: center; font-size: 32px; font-weight: 600; letter-spacing: 8px; margin: 32px 0; border: 1px solid #000;">
-								%s
-							</div>
-							<p style="margin: 0 0 8px 0; color: #666; font-size: 14px;">This code will expire in 10 minutes.</p>
-							<p style="margin: 0; color: #666; font-size: 14px;">If you didn't request this code, please ignore this email.</p>
-						</div>
-						
-						<!-- Footer -->
-						<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-							<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-							<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-						</div>
-					</div>
-				</body>
-				</html>
-			`, name, verificationCode),
-			IsHTML: true,
+		emailMessage, err := utils.NewTemplateEmail(email, "verification_code", locale, echo.Map{"Name": name, "Code": verificationCode, "Welcome": false})
+		if err != nil {
+			log.Printf("Failed to build verification code email to %s: %v", email, err)
+			return
 		}
 
 		if err := utils.SendEmail(emailConfig, emailMessage); err != nil {
 			log.Printf("Failed to send verification email to %s: %v", email, err)
 		}
-	}(req.Email, user.Name, code)
+	}(req.Email, user.Name, code, requestEmailLocale(c))
 
 	return c.JSON(http.StatusOK, echo.Map{
 		"message": "Verification code will be sent to your email shortly",
@@ -700,12 +612,12 @@ func VerifyCode(c echo.Context) error {
 	}
 
 	// Send welcome email after successful verification asynchronously
-	go func(email, name string) {
+	go func(email, name, locale string) {
 		emailConfig := utils.LoadEmailConfig()
-		if err := utils.SendWelcomeEmail(emailConfig, email, name); err != nil {
+		if err := utils.SendWelcomeEmail(emailConfig, email, locale, name); err != nil {
 			log.Printf("Failed to send welcome email to %s: %v", email, err)
 		}
-	}(user.Email, user.Name)
+	}(user.Email, user.Name, requestEmailLocale(c))
 
 	return c.JSON(http.StatusOK, echo.Map{
 		"message": "Email verified successfully",
@@ -785,12 +697,12 @@ func VerifyEmail(c echo.Context) error {
 	}
 
 	// Send welcome email after successful verification asynchronously
-	go func(email, name string) {
+	go func(email, name, locale string) {
 		emailConfig := utils.LoadEmailConfig()
-		if err := utils.SendWelcomeEmail(emailConfig, email, name); err != nil {
+		if err := utils.SendWelcomeEmail(emailConfig, email, locale, name); err != nil {
 			log.Printf("Failed to send welcome email to %s: %v", email, err)
 		}
-	}(user.Email, user.Name)
+	}(user.Email, user.Name, requestEmailLocale(c))
 
 	return c.JSON(http.StatusOK, echo.Map{
 		"message": "Email verified successfully",
@@ -866,48 +778,18 @@ func ResendVerification(c echo.Context) error {
 	}
 
 	// Send verification email asynchronously
-	go func(email, name, verificationCode string) {
+	go func(email, name, verificationCode, locale string) {
 		emailConfig := utils.LoadEmailConfig()
-		emailMessage := &utils.EmailMessage{
-			To:      []string{email},
-			Subject: "Your Verification Code",
-			Body: fmt.Sprintf(`
-				<html>
-				<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-					<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-						<!-- Header -->
-						<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-							<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-						</div>
-						
-						<!-- Content -->
-						<div style="padding: 40px 20px;">
-							<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">Email Verification</h2>
-							<p style="margin: 0 0 16px 0; color: #000;">Hi %s,</p>
-							<p style="margin: 0 0 24px 0; color: #000;">Your verification code is:</p>
-							<div style="background-color: #f5f5f5; padding: 20px; text-align: center; font-size: 32px; font-weight: 600; letter-spacing: 8px; margin: 32px 0; border: 1px solid #000;">
-								%s
-							</div>
-							<p style="margin: 0 0 8px 0; color: #666; font-size: 14px;">This code will expire in 10 minutes.</p>
-							<p style="margin: 0; color: #666; font-size: 14px;">If you didn't request this code, please ignore this email.</p>
-						</div>
-						
-						<!-- Footer -->
-						<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-							<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-							<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-						</div>
-					</div>
-				</body>
-				</html>
-			`, name, verificationCode),
-			IsHTML: true,
+		emailMessage, err := utils.NewTemplateEmail(email, "verification_code", locale, echo.Map{"Name": name, "Code": verificationCode, "Welcome": false})
+		if err != nil {
+			log.Printf("Failed to build verification code email to %s: %v", email, err)
+			return
 		}
 
 		if err := utils.SendEmail(emailConfig, emailMessage); err != nil {
 			log.Printf("Failed to send verification email to %s: %v", email, err)
 		}
-	}(req.Email, user.Name, code)
+	}(req.Email, user.Name, code, requestEmailLocale(c))
 
 	return c.JSON(http.StatusOK, echo.Map{
 		"message": "If an account with that email exists, a verification code will be sent shortly",
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"backend/models"
+	"backend/utils"
+)
+
+// blindReviewRevealStatuses are the application statuses at which an applicant's
+// identity is revealed even on projects running blind review
+var blindReviewRevealStatuses = map[string]bool{
+	"interview": true,
+	"accepted":  true,
+	"approved":  true,
+}
+
+// isApplicantHidden reports whether an application's identifying fields must be redacted
+func isApplicantHidden(project models.Projects, status string) bool {
+	return project.BlindReview && !blindReviewRevealStatuses[status]
+}
+
+// redactStudentForBlindReview clears every profile field that could identify an applicant, keeping the
+// skills, degree and interests reviewers judge them on
+func redactStudentForBlindReview(student *models.Students) {
+	student.Uid = ""
+	student.Institution = ""
+	student.Location = ""
+	student.Dates = ""
+	student.Experience = ""
+	student.Projects = nil
+	student.PlatformProjects = nil
+	student.Activities = nil
+	student.Resume = ""
+	student.Publications = ""
+	student.EducationDetails = nil
+	student.ExperienceDetails = nil
+	student.PublicationsList = nil
+	student.ProjectsDetails = nil
+	student.Summary = ""
+	student.PersonalInfo = ""
+}
+
+// redactApplicantForBlindReview hides an applicant behind their per-project handle: the user's name is
+// replaced, their contact details, profile and application links are cleared
+func redactApplicantForBlindReview(app *models.ProjRequests, user *models.User, student *models.Students) {
+	user.Name = utils.GenerateApplicantHandle(app.PID, app.UID)
+	user.Uid = ""
+	user.Email = ""
+	app.CVLink = ""
+	app.PublicationsLink = ""
+	redactStudentForBlindReview(student)
+}
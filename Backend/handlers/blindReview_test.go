@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"backend/models"
+)
+
+func TestRedactApplicantForBlindReviewClearsIdentifyingFields(t *testing.T) {
+	app := models.ProjRequests{
+		PID:              "proj-1",
+		UID:              "student-uid",
+		CVLink:           "https://cv.example/alex.pdf",
+		PublicationsLink: "https://scholar.example/alex",
+		Motivation:       "I want to learn",
+	}
+	user := models.User{Uid: "student-uid", Name: "Alex Doe", Email: "alex@uni.example"}
+	student := models.Students{
+		Uid:               "student-uid",
+		Institution:       "Example University",
+		Degree:            "BSc",
+		Location:          "Springfield",
+		Dates:             "2022-2026",
+		Experience:        "Intern at Acme",
+		Projects:          []string{"Alex's compiler"},
+		PlatformProjects:  []int64{7},
+		Skills:            []string{"Go"},
+		Activities:        []string{"Springfield Rowing Club captain"},
+		Resume:            "https://cv.example/resume.pdf",
+		Publications:      "https://scholar.example/alex",
+		ResearchInterest:  "Systems",
+		EducationDetails:  models.EducationArray{{}},
+		ExperienceDetails: models.ExperienceArray{{}},
+		PublicationsList:  models.PublicationArray{{}},
+		ProjectsDetails:   models.ProjectArray{{}},
+		Summary:           "Alex Doe, student at Example University",
+		PersonalInfo:      `{"phone":"555-0100","linkedin":"alexdoe"}`,
+	}
+
+	redactApplicantForBlindReview(&app, &user, &student)
+
+	identifying := map[string]any{
+		"user.Uid":                  user.Uid,
+		"user.Email":                user.Email,
+		"app.CVLink":                app.CVLink,
+		"app.PublicationsLink":      app.PublicationsLink,
+		"student.Uid":               student.Uid,
+		"student.Institution":       student.Institution,
+		"student.Location":          student.Location,
+		"student.Dates":             student.Dates,
+		"student.Experience":        student.Experience,
+		"student.Projects":          student.Projects,
+		"student.PlatformProjects":  student.PlatformProjects,
+		"student.Resume":            student.Resume,
+		"student.Publications":      student.Publications,
+		"student.EducationDetails":  student.EducationDetails,
+		"student.ExperienceDetails": student.ExperienceDetails,
+		"student.PublicationsList":  student.PublicationsList,
+		"student.ProjectsDetails":   student.ProjectsDetails,
+		"student.Summary":           student.Summary,
+		"student.PersonalInfo":      student.PersonalInfo,
+	}
+	for field, value := range identifying {
+		if !reflect.ValueOf(value).IsZero() {
+			t.Errorf("%s was not redacted: %v", field, value)
+		}
+	}
+
+	if user.Name == "Alex Doe" || user.Name == "" || strings.Contains(user.Name, "Alex") {
+		t.Errorf("name should be replaced by the applicant handle, got %q", user.Name)
+	}
+	// Fields reviewers judge applicants on stay visible, as does the uid the handle is derived from
+	if student.Degree != "BSc" || len(student.Skills) != 1 || student.ResearchInterest != "Systems" || app.Motivation == "" || app.UID == "" {
+		t.Error("non-identifying fields should be kept")
+	}
+}
@@ -0,0 +1,512 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// maxBulkApplications caps how many applications a single bulk request may touch
+const maxBulkApplications = 200
+
+// BulkItemResult reports the outcome of a bulk action for a single application
+type BulkItemResult struct {
+	ApplicationID uint   `json:"applicationId"`
+	Success       bool   `json:"success"`
+	Status        string `json:"status,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// loadBulkApplications validates the requested IDs and returns the matching applications of the project
+// keyed by ID, together with a pre-filled result slot for every requested ID
+func loadBulkApplications(db *gorm.DB, projectID string, applicationIDs []uint) (map[uint]models.ProjRequests, []BulkItemResult, error) {
+	var applications []models.ProjRequests
+	if err := db.Where("p_id = ? AND id IN ?", projectID, applicationIDs).Find(&applications).Error; err != nil {
+		return nil, nil, err
+	}
+
+	found := make(map[uint]models.ProjRequests, len(applications))
+	for _, app := range applications {
+		found[app.ID] = app
+	}
+
+	results := make([]BulkItemResult, 0, len(applicationIDs))
+	seen := make(map[uint]bool, len(applicationIDs))
+	for _, id := range applicationIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		result := BulkItemResult{ApplicationID: id}
+		if _, ok := found[id]; !ok {
+			result.Error = "Application not found"
+		}
+		results = append(results, result)
+	}
+
+	return found, results, nil
+}
+
+// validateBulkApplicationIDs checks the size of a bulk request
+func validateBulkApplicationIDs(applicationIDs []uint) string {
+	if len(applicationIDs) == 0 {
+		return "At least one application ID is required"
+	}
+	if len(applicationIDs) > maxBulkApplications {
+		return fmt.Sprintf("A bulk request may include at most %d applications", maxBulkApplications)
+	}
+	return ""
+}
+
+// countBulkSuccesses counts the successful items of a bulk result
+func countBulkSuccesses(results []BulkItemResult) int {
+	succeeded := 0
+	for _, result := range results {
+		if result.Success {
+			succeeded++
+		}
+	}
+	return succeeded
+}
+
+// renderFeedbackTemplate fills the {{name}}, {{project}} and {{status}} placeholders of a feedback template
+func renderFeedbackTemplate(template, studentName, projectName, status string) string {
+	return strings.NewReplacer(
+		"{{name}}", studentName,
+		"{{project}}", projectName,
+		"{{status}}", status,
+	).Replace(template)
+}
+
+// sendBulkApplicationEmails emails each application's student over a single SMTP connection, or queues it
+// for their digest according to their preference for eventType; summarize gives the digest line.
+// The student records are fetched with one query instead of one per application.
+func sendBulkApplicationEmails(eventType string, applications []models.ProjRequests, build func(app models.ProjRequests, student models.User, locale string) (*utils.EmailMessage, error), summarize func(app models.ProjRequests) string) {
+	if len(applications) == 0 {
+		return
+	}
+
+	go func() {
+		uids := make([]string, 0, len(applications))
+		for _, app := range applications {
+			uids = append(uids, app.UID)
+		}
+
+		var students []models.User
+		if err := config.DB.Where("uid IN ?", uids).Find(&students).Error; err != nil {
+			log.Printf("Failed to fetch students for bulk notification: %v", err)
+			return
+		}
+
+		studentMap := make(map[string]models.User, len(students))
+		for _, student := range students {
+			studentMap[student.Uid] = student
+		}
+		locales := loadEmailLocales(uids)
+
+		recipients := make([]string, 0, len(applications))
+		messages := make([]*utils.EmailMessage, 0, len(applications))
+		summaries := make([]string, 0, len(applications))
+		for _, app := range applications {
+			student, ok := studentMap[app.UID]
+			if !ok {
+				continue
+			}
+
+			message, err := build(app, student, locales[student.Uid])
+			if err != nil {
+				log.Printf("Failed to build bulk notification to %s: %v", student.Email, err)
+				continue
+			}
+			recipients = append(recipients, student.Uid)
+			messages = append(messages, message)
+			summaries = append(summaries, summarize(app))
+		}
+
+		dispatchEmails(eventType, recipients, messages, summaries)
+	}()
+}
+
+// BulkUpdateApplicationStatus changes the status of many applications of a project in one transaction
+func BulkUpdateApplicationStatus(c echo.Context) error {
+	projectID := c.Param("id")
+	if projectID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Project ID is required"})
+	}
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a faculty member
+	if userData.GetUserType() != models.UserTypeFaculty {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can update application status"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		ApplicationIDs []uint `json:"applicationIds"`
+		Status         string `json:"status"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if msg := validateBulkApplicationIDs(requestBody.ApplicationIDs); msg != "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": msg})
+	}
+
+	if !isValidApplicationStatus(requestBody.Status) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid status. Must be one of: accepted, rejected, waitlisted, interview, under_review, approved"})
+	}
+
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Check if project belongs to the professor (by creator)
+	var project models.Projects
+	if err := tx.Where("project_id = ? AND creator_id = ?", projectID, userData.GetUID()).First(&project).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	found, results, err := loadBulkApplications(tx, projectID, requestBody.ApplicationIDs)
+	if err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch applications"})
+	}
+
+	var changed []models.ProjRequests
+	previousStatuses := make(map[uint]string)
+	released := make(map[uint]models.ProjRequests)
+	for i := range results {
+		application, ok := found[results[i].ApplicationID]
+		if !ok {
+			continue
+		}
+
+		// Skip applications already in the requested status so students are not notified twice
+		if application.Status == requestBody.Status {
+			results[i].Success = true
+			results[i].Status = application.Status
+			continue
+		}
+
+		// Leaving the interview stage frees any upcoming booked slot
+		previousStatuses[application.ID] = application.Status
+		updates := applicationStatusUpdates(requestBody.Status)
+		if booking := releaseInterviewForStatus(application, requestBody.Status, updates); booking != nil {
+			released[application.ID] = *booking
+		}
+		if err := tx.Model(&application).Updates(updates).Error; err != nil {
+			tx.Rollback()
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update application status"})
+		}
+
+		// If accepted, add user to project's working users
+		if requestBody.Status == "accepted" {
+			if err := addWorkingUser(tx, projectID, application.UID); err != nil {
+				tx.Rollback()
+				return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to add user to project"})
+			}
+		}
+
+		results[i].Success = true
+		results[i].Status = requestBody.Status
+		changed = append(changed, application)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
+	}
+
+	// Notify every affected student in one batch
+	notifications := make([]models.Notification, 0, len(changed))
+	for _, app := range changed {
+		notifications = append(notifications, statusChangeNotification(app, project.Name, requestBody.Status))
+	}
+	createNotifications(notifications)
+
+	for _, app := range changed {
+		emitApplicationStatusChanged(app, project, previousStatuses[app.ID], requestBody.Status)
+	}
+
+	// Released slots have their calendar invites withdrawn in the status email
+	var professor models.User
+	if len(released) > 0 {
+		if err := config.DB.Where("uid = ?", project.CreatorID).First(&professor).Error; err != nil {
+			log.Printf("Failed to fetch professor for interview cancellations: %v", err)
+		}
+	}
+
+	sendBulkApplicationEmails(models.NotificationStatusChanged, changed, func(app models.ProjRequests, student models.User, locale string) (*utils.EmailMessage, error) {
+		emailMessage, err := buildStatusUpdateEmail(student.Email, locale, student.Name, project.Name, requestBody.Status)
+		if booking, ok := released[app.ID]; ok && err == nil {
+			emailMessage.Attachments = []utils.EmailAttachment{interviewCancellationInvite(booking, project.Name, professor.Email, student.Email)}
+		}
+		return emailMessage, err
+	}, func(app models.ProjRequests) string {
+		return statusChangeNotification(app, project.Name, requestBody.Status).Body
+	})
+
+	succeeded := countBulkSuccesses(results)
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":   fmt.Sprintf("Updated %d of %d applications", succeeded, len(results)),
+		"results":   results,
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+	})
+}
+
+// BulkSendApplicationFeedback sends templated feedback to the students of many applications at once
+func BulkSendApplicationFeedback(c echo.Context) error {
+	projectID := c.Param("id")
+	if projectID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Project ID is required"})
+	}
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a faculty member
+	if userData.GetUserType() != models.UserTypeFaculty {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can send feedback"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		ApplicationIDs []uint `json:"applicationIds"`
+		Feedback       string `json:"feedback"` // Supports {{name}}, {{project}} and {{status}} placeholders
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if msg := validateBulkApplicationIDs(requestBody.ApplicationIDs); msg != "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": msg})
+	}
+
+	if strings.TrimSpace(requestBody.Feedback) == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Feedback message is required"})
+	}
+
+	// Check if project belongs to the professor
+	var project models.Projects
+	if err := config.DB.Where("project_id = ? AND creator_id = ?", projectID, userData.GetUID()).First(&project).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	// Fetch professor details
+	var professor models.User
+	if err := config.DB.Where("uid = ?", userData.GetUID()).First(&professor).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Professor not found"})
+	}
+
+	found, results, err := loadBulkApplications(config.DB, projectID, requestBody.ApplicationIDs)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch applications"})
+	}
+
+	// Fetch all targeted students in one query so the template can be rendered per student
+	uids := make([]string, 0, len(found))
+	for _, app := range found {
+		uids = append(uids, app.UID)
+	}
+
+	var students []models.User
+	if err := config.DB.Where("uid IN ?", uids).Find(&students).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch students"})
+	}
+
+	studentNames := make(map[string]string, len(students))
+	for _, student := range students {
+		studentNames[student.Uid] = student.Name
+	}
+
+	var targets []models.ProjRequests
+	var bodies []string
+	for i := range results {
+		application, ok := found[results[i].ApplicationID]
+		if !ok {
+			continue
+		}
+		studentName, ok := studentNames[application.UID]
+		if !ok {
+			results[i].Error = "Student not found"
+			continue
+		}
+		results[i].Success = true
+		results[i].Status = application.Status
+		targets = append(targets, application)
+		bodies = append(bodies, renderFeedbackTemplate(requestBody.Feedback, studentName, project.Name, application.Status))
+	}
+
+	// Store every rendered feedback in its application's thread in one transaction
+	if err := config.DB.Transaction(func(tx *gorm.DB) error {
+		return saveFeedbackMessages(tx, userData.GetUID(), targets, bodies)
+	}); err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save feedback"})
+	}
+
+	notifications := make([]models.Notification, 0, len(targets))
+	for _, app := range targets {
+		notifications = append(notifications, feedbackNotification(app, project.Name))
+	}
+	createNotifications(notifications)
+
+	sendBulkApplicationEmails(models.NotificationFeedbackReceived, targets, func(app models.ProjRequests, student models.User, locale string) (*utils.EmailMessage, error) {
+		feedback := renderFeedbackTemplate(requestBody.Feedback, student.Name, project.Name, app.Status)
+		return buildFeedbackEmail(student.Email, locale, student.Name, professor.Name, project.Name, feedback)
+	}, func(app models.ProjRequests) string {
+		return feedbackNotification(app, project.Name).Body
+	})
+
+	succeeded := countBulkSuccesses(results)
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":   fmt.Sprintf("Feedback sent for %d of %d applications", succeeded, len(results)),
+		"results":   results,
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+	})
+}
+
+// BulkScheduleInterview schedules the same interview slot details for many applications in one transaction
+func BulkScheduleInterview(c echo.Context) error {
+	projectID := c.Param("id")
+	if projectID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Project ID is required"})
+	}
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a faculty member
+	if userData.GetUserType() != models.UserTypeFaculty {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can schedule interviews"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		ApplicationIDs   []uint `json:"applicationIds"`
+		InterviewDate    string `json:"interviewDate"`
+		InterviewTime    string `json:"interviewTime"`
+		InterviewDetails string `json:"interviewDetails"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if msg := validateBulkApplicationIDs(requestBody.ApplicationIDs); msg != "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": msg})
+	}
+
+	if requestBody.InterviewDate == "" || requestBody.InterviewTime == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Interview date and time are required"})
+	}
+
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Check if project belongs to the professor
+	var project models.Projects
+	if err := tx.Where("project_id = ? AND creator_id = ?", projectID, userData.GetUID()).First(&project).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	found, results, err := loadBulkApplications(tx, projectID, requestBody.ApplicationIDs)
+	if err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch applications"})
+	}
+
+	updates := map[string]interface{}{
+		"status":              "interview",
+		"decided_at":          nil,
+		"interview_date":      requestBody.InterviewDate,
+		"interview_time":      requestBody.InterviewTime,
+		"interview_details":   requestBody.InterviewDetails,
+		"interview_starts_at": nil,
+		"interview_ends_at":   nil,
+	}
+
+	var scheduled []models.ProjRequests
+	for i := range results {
+		application, ok := found[results[i].ApplicationID]
+		if !ok {
+			continue
+		}
+
+		if application.Status == "accepted" || application.Status == "rejected" {
+			results[i].Error = fmt.Sprintf("Cannot schedule an interview for a %s application", application.Status)
+			continue
+		}
+
+		// The shared details replace any slot the applicant had booked
+		applicationUpdates := maps.Clone(updates)
+		releaseInterviewSlot(application, applicationUpdates)
+
+		if err := tx.Model(&application).Updates(applicationUpdates).Error; err != nil {
+			tx.Rollback()
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to schedule interview"})
+		}
+
+		results[i].Success = true
+		results[i].Status = "interview"
+		scheduled = append(scheduled, application)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
+	}
+
+	notifications := make([]models.Notification, 0, len(scheduled))
+	for _, app := range scheduled {
+		notifications = append(notifications, interviewNotification(app, project.Name, requestBody.InterviewDate, requestBody.InterviewTime, false))
+	}
+	createNotifications(notifications)
+
+	// Fetch professor details for the email
+	var professor models.User
+	if err := config.DB.Where("uid = ?", userData.GetUID()).First(&professor).Error; err != nil {
+		log.Printf("Failed to fetch professor for bulk interview email: %v", err)
+	}
+
+	sendBulkApplicationEmails(models.NotificationInterviewScheduled, scheduled, func(app models.ProjRequests, student models.User, locale string) (*utils.EmailMessage, error) {
+		emailMessage, err := buildInterviewEmail(student.Email, locale, student.Name, professor.Name, project.Name, requestBody.InterviewDate, requestBody.InterviewTime, requestBody.InterviewDetails)
+		// Applications still carry the slot they had booked; withdraw its invite
+		if err == nil && app.InterviewStartsAt != nil {
+			emailMessage.Attachments = []utils.EmailAttachment{interviewCancellationInvite(app, project.Name, professor.Email, student.Email)}
+		}
+		return emailMessage, err
+	}, func(app models.ProjRequests) string {
+		return interviewNotification(app, project.Name, requestBody.InterviewDate, requestBody.InterviewTime, false).Body
+	})
+
+	succeeded := countBulkSuccesses(results)
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":   fmt.Sprintf("Scheduled interviews for %d of %d applications", succeeded, len(results)),
+		"results":   results,
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+	})
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Feeds cover events from the recent past up to a year ahead
+const (
+	calendarFeedLookback  = 30 * 24 * time.Hour
+	calendarFeedLookahead = 365 * 24 * time.Hour
+)
+
+var roadmapDurationPattern = regexp.MustCompile(`(?i)(\d+)\s*(day|week|month)`)
+
+// interviewCalendarEvent builds the calendar entry for a booked interview
+func interviewCalendarEvent(application models.ProjRequests, projectName, professorEmail, studentEmail string, cancelled bool) utils.CalendarEvent {
+	event := utils.CalendarEvent{
+		UID:         fmt.Sprintf("interview-%d@feelslikesummer", application.ID),
+		Summary:     fmt.Sprintf("Interview: %s", projectName),
+		Description: application.InterviewDetails,
+		Sequence:    int(time.Now().Unix()), // Always increases so clients apply reschedules and cancellations
+		Cancelled:   cancelled,
+		Organizer:   professorEmail,
+	}
+	if studentEmail != "" {
+		event.Attendees = []string{studentEmail}
+	}
+	if application.InterviewStartsAt != nil && application.InterviewEndsAt != nil {
+		event.Start = *application.InterviewStartsAt
+		event.End = *application.InterviewEndsAt
+	}
+	return event
+}
+
+// parseProjectDeadline parses a project deadline in any of the formats accepted elsewhere
+func parseProjectDeadline(deadline string) (time.Time, bool) {
+	for _, format := range []string{"2006-01-02", "02/01/2006", "01-02-2006"} {
+		if parsed, err := time.Parse(format, deadline); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// roadmapNodeDays estimates how many days a roadmap node takes from its free-text duration
+func roadmapNodeDays(duration string) int {
+	match := roadmapDurationPattern.FindStringSubmatch(duration)
+	if match == nil {
+		return 7 // Generated roadmaps are planned week by week
+	}
+	n, _ := strconv.Atoi(match[1])
+	switch strings.ToLower(match[2]) {
+	case "day":
+		return n
+	case "month":
+		return n * 30
+	default:
+		return n * 7
+	}
+}
+
+// roadmapMilestoneEvents lays out a roadmap's nodes back to back from its creation date
+func roadmapMilestoneEvents(roadmap models.Roadmap) []utils.CalendarEvent {
+	var structure models.RoadmapStructure
+	if err := json.Unmarshal([]byte(roadmap.RoadmapData), &structure); err != nil {
+		return nil
+	}
+
+	events := []utils.CalendarEvent{}
+	due := roadmap.CreatedAt
+	for _, node := range structure.Nodes {
+		due = due.AddDate(0, 0, roadmapNodeDays(node.Duration))
+		day := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+		events = append(events, utils.CalendarEvent{
+			UID:         fmt.Sprintf("roadmap-%d-%s@feelslikesummer", roadmap.ID, node.ID),
+			Summary:     fmt.Sprintf("Milestone: %s", node.Title),
+			Description: fmt.Sprintf("%s\n\nRoadmap: %s", node.Description, roadmap.Title),
+			Start:       day,
+			End:         day.AddDate(0, 0, 1),
+			AllDay:      true,
+		})
+	}
+	return events
+}
+
+// deadlineCalendarEvent builds an all-day entry for a project deadline
+func deadlineCalendarEvent(project models.Projects) (utils.CalendarEvent, bool) {
+	if project.Deadline == nil || *project.Deadline == "" {
+		return utils.CalendarEvent{}, false
+	}
+	day, ok := parseProjectDeadline(*project.Deadline)
+	if !ok {
+		return utils.CalendarEvent{}, false
+	}
+	return utils.CalendarEvent{
+		UID:         fmt.Sprintf("deadline-%s@feelslikesummer", project.ProjectID),
+		Summary:     fmt.Sprintf("Deadline: %s", project.Name),
+		Description: project.SDesc,
+		Start:       day,
+		End:         day.AddDate(0, 0, 1),
+		AllDay:      true,
+	}, true
+}
+
+// collectCalendarEvents gathers the interviews, project deadlines and roadmap milestones of a user
+func collectCalendarEvents(user models.User) []utils.CalendarEvent {
+	now := time.Now()
+	from, to := now.Add(-calendarFeedLookback), now.Add(calendarFeedLookahead)
+	events := []utils.CalendarEvent{}
+
+	var projects []models.Projects
+	var interviews []models.ProjRequests
+	if user.Type == models.UserTypeFaculty {
+		config.DB.Where("creator_id = ?", user.Uid).Find(&projects)
+		interviews, _ = loadProfessorBookings(config.DB, user.Uid, from, to, 0)
+	} else {
+		config.DB.Where("project_id IN (?)", config.DB.Model(&models.ProjRequests{}).
+			Select("p_id").Where("uid = ? AND status != ?", user.Uid, "rejected")).
+			Find(&projects)
+		config.DB.Where("uid = ? AND status = ? AND interview_starts_at BETWEEN ? AND ?", user.Uid, "interview", from, to).Find(&interviews)
+	}
+
+	projectNames := make(map[string]string, len(projects))
+	for _, project := range projects {
+		projectNames[project.ProjectID] = project.Name
+		if event, ok := deadlineCalendarEvent(project); ok && event.End.After(from) && event.Start.Before(to) {
+			events = append(events, event)
+		}
+	}
+
+	for _, interview := range interviews {
+		name, ok := projectNames[interview.PID]
+		if !ok {
+			var project models.Projects
+			if err := config.DB.Where("project_id = ?", interview.PID).First(&project).Error; err != nil {
+				continue
+			}
+			name = project.Name
+		}
+		events = append(events, interviewCalendarEvent(interview, name, "", "", false))
+	}
+
+	var roadmaps []models.Roadmap
+	config.DB.Where("user_id = ?", user.Uid).Find(&roadmaps)
+	for _, roadmap := range roadmaps {
+		for _, event := range roadmapMilestoneEvents(roadmap) {
+			if event.End.After(from) && event.Start.Before(to) {
+				events = append(events, event)
+			}
+		}
+	}
+
+	return events
+}
+
+// calendarFeedURL builds the public subscription URL for a feed token
+func calendarFeedURL(c echo.Context, token string) string {
+	return fmt.Sprintf("%s://%s/calendar/%s.ics", c.Scheme(), c.Request().Host, token)
+}
+
+// GetCalendarFeedURL returns the user's calendar subscription URL, creating a token on first use
+func GetCalendarFeedURL(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	var feed models.CalendarFeedToken
+	if err := config.DB.Where("uid = ?", userData.GetUID()).First(&feed).Error; err != nil {
+		token, err := utils.GenerateFeedToken()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to generate feed token"})
+		}
+
+		feed = models.CalendarFeedToken{UID: userData.GetUID(), Token: token}
+		if err := config.DB.Create(&feed).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to create calendar feed"})
+		}
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"url": calendarFeedURL(c, feed.Token)})
+}
+
+// RotateCalendarFeedURL replaces the user's feed token, invalidating previously shared URLs
+func RotateCalendarFeedURL(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	token, err := utils.GenerateFeedToken()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to generate feed token"})
+	}
+
+	// Hard delete so the unique index on uid frees up
+	if err := config.DB.Unscoped().Where("uid = ?", userData.GetUID()).Delete(&models.CalendarFeedToken{}).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to rotate calendar feed"})
+	}
+
+	feed := models.CalendarFeedToken{UID: userData.GetUID(), Token: token}
+	if err := config.DB.Create(&feed).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to rotate calendar feed"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Calendar feed URL rotated successfully",
+		"url":     calendarFeedURL(c, feed.Token),
+	})
+}
+
+// GetCalendarFeed serves a user's ICS feed to calendar clients authenticated by the URL token
+func GetCalendarFeed(c echo.Context) error {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	var feed models.CalendarFeedToken
+	if token == "" || config.DB.Where("token = ?", token).First(&feed).Error != nil {
+		return c.String(http.StatusNotFound, "Calendar feed not found")
+	}
+
+	var user models.User
+	if err := config.DB.Where("uid = ?", feed.UID).First(&user).Error; err != nil {
+		return c.String(http.StatusNotFound, "Calendar feed not found")
+	}
+
+	events := collectCalendarEvents(user)
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `inline; filename="feels-like-summer.ics"`)
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", []byte(utils.BuildCalendar(utils.CalendarMethodPublish, "Feels Like Summer", events)))
+}
@@ -0,0 +1,529 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	directMessageMaxLength      = 5000 // Characters per message
+	directMessageMaxAttachments = 5    // Links per message
+)
+
+// conversationPair orders two UIDs the way Conversation stores them
+func conversationPair(uid1, uid2 string) (string, string) {
+	if uid1 < uid2 {
+		return uid1, uid2
+	}
+	return uid2, uid1
+}
+
+// isHTTPLink reports whether link is an absolute http or https URL
+func isHTTPLink(link string) bool {
+	parsed, err := url.Parse(link)
+	return err == nil && parsed.Host != "" && (parsed.Scheme == "http" || parsed.Scheme == "https")
+}
+
+// validateAttachmentLinks trims attachment links and reports the first one that is not an http(s) URL
+func validateAttachmentLinks(links []string) (pq.StringArray, string) {
+	attachments := pq.StringArray{}
+	for _, link := range links {
+		link = strings.TrimSpace(link)
+		if link == "" {
+			continue
+		}
+		if !isHTTPLink(link) {
+			return nil, link
+		}
+		attachments = append(attachments, link)
+	}
+	return attachments, ""
+}
+
+// sharesApplication reports whether the student has applied to a project the faculty member runs or reviews
+func sharesApplication(studentUID, facultyUID string) bool {
+	var count int64
+	config.DB.Table("proj_requests").
+		Joins("JOIN projects ON projects.project_id = proj_requests.p_id").
+		Where("proj_requests.uid = ? AND proj_requests.deleted_at IS NULL", studentUID).
+		Where("projects.creator_id = ? OR ? = ANY(projects.reviewers)", facultyUID, facultyUID).
+		Count(&count)
+	return count > 0
+}
+
+// checkConversationStart reports why sender may not open a conversation with recipient, or "" if they may.
+// Students never message each other; with RequireApplication a student and a faculty member must share an application.
+func checkConversationStart(sender, recipient models.User, rules utils.MessagingRules) string {
+	switch {
+	case sender.Type == models.UserTypeStudent && recipient.Type == models.UserTypeStudent:
+		return "Students can only message faculty"
+	case !rules.RequireApplication:
+		return ""
+	case sender.Type == models.UserTypeStudent && recipient.Type == models.UserTypeFaculty:
+		if !sharesApplication(sender.Uid, recipient.Uid) {
+			return "You can only message faculty of projects you have applied to"
+		}
+	case sender.Type == models.UserTypeFaculty && recipient.Type == models.UserTypeStudent:
+		if !sharesApplication(recipient.Uid, sender.Uid) {
+			return "You can only message students who applied to your projects"
+		}
+	}
+	return ""
+}
+
+// checkSendLimits reports why the sender must wait before sending another message, or "" if they may send
+func checkSendLimits(senderUID string, conversation models.Conversation, rules utils.MessagingRules) string {
+	if rules.MaxPerHour > 0 {
+		var sentLastHour int64
+		config.DB.Model(&models.DirectMessage{}).
+			Where("sender_uid = ? AND created_at > ?", senderUID, time.Now().Add(-time.Hour)).
+			Count(&sentLastHour)
+		if sentLastHour >= int64(rules.MaxPerHour) {
+			return fmt.Sprintf("You can send at most %d messages per hour", rules.MaxPerHour)
+		}
+	}
+
+	if rules.MaxUnread > 0 {
+		var unread int64
+		config.DB.Model(&models.DirectMessage{}).
+			Where("conversation_id = ? AND sender_uid = ? AND read_at IS NULL", conversation.ID, senderUID).
+			Count(&unread)
+		if unread >= int64(rules.MaxUnread) {
+			return "Wait for a reply before sending more messages"
+		}
+	}
+	return ""
+}
+
+// validateDirectMessage trims a message and its links, returning an error message if they are not valid
+func validateDirectMessage(body string, links []string) (string, pq.StringArray, string) {
+	body = strings.TrimSpace(body)
+	attachments, invalid := validateAttachmentLinks(links)
+	switch {
+	case invalid != "":
+		return "", nil, "Attachments must be http or https links: " + invalid
+	case body == "" && len(attachments) == 0:
+		return "", nil, "Message cannot be empty"
+	case len([]rune(body)) > directMessageMaxLength:
+		return "", nil, fmt.Sprintf("Message cannot be longer than %d characters", directMessageMaxLength)
+	case len(attachments) > directMessageMaxAttachments:
+		return "", nil, fmt.Sprintf("A message can have at most %d attachments", directMessageMaxAttachments)
+	}
+	return body, attachments, ""
+}
+
+// sendDirectMessage stores a message, pushes it to the recipient's open streams and, if the recipient is
+// offline, emails them. Only the first unread message of a conversation is emailed, so a burst of messages
+// sends one email until the recipient catches up.
+func sendDirectMessage(conversation models.Conversation, sender models.User, body string, attachments pq.StringArray) (models.DirectMessage, error) {
+	recipientUID := conversation.OtherParticipant(sender.Uid)
+	message := models.DirectMessage{
+		ConversationID: conversation.ID,
+		SenderUID:      sender.Uid,
+		RecipientUID:   recipientUID,
+		Body:           body,
+		Attachments:    attachments,
+	}
+
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Create(&message).Error; err != nil {
+		tx.Rollback()
+		return message, err
+	}
+	if err := tx.Model(&conversation).Update("last_message_at", message.CreatedAt).Error; err != nil {
+		tx.Rollback()
+		return message, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return message, err
+	}
+
+	publishEvent(recipientUID, models.NotificationDirectMessage, message)
+
+	if utils.GetEventBroker().Connected(recipientUID) {
+		return message, nil
+	}
+
+	var unread int64
+	config.DB.Model(&models.DirectMessage{}).
+		Where("conversation_id = ? AND recipient_uid = ? AND read_at IS NULL", conversation.ID, recipientUID).
+		Count(&unread)
+	if unread != 1 {
+		return message, nil
+	}
+
+	go func() {
+		var recipient models.User
+		if err := config.DB.Where("uid = ?", recipientUID).First(&recipient).Error; err != nil {
+			log.Printf("Failed to fetch recipient for direct message email: %v", err)
+			return
+		}
+
+		emailMessage, err := utils.NewTemplateEmail(recipient.Email, "direct_message", loadEmailLocale(recipient.Uid), echo.Map{
+			"RecipientName":   recipient.Name,
+			"SenderName":      sender.Name,
+			"Body":            body,
+			"Attachments":     []string(attachments),
+			"ConversationURL": fmt.Sprintf("%s/messages/%d", os.Getenv("FRONTEND_URL"), conversation.ID),
+		})
+		if err != nil {
+			log.Printf("Failed to build direct message email to %s: %v", recipient.Email, err)
+			return
+		}
+
+		dispatchEmail(models.NotificationDirectMessage, recipient.Uid, emailMessage,
+			fmt.Sprintf("%s sent you a message.", sender.Name))
+	}()
+
+	return message, nil
+}
+
+// markConversationRead records a read receipt on every message of the conversation sent to uid
+func markConversationRead(conversationID uint, uid string) error {
+	return config.DB.Model(&models.DirectMessage{}).
+		Where("conversation_id = ? AND recipient_uid = ? AND read_at IS NULL", conversationID, uid).
+		Update("read_at", time.Now()).Error
+}
+
+// findMyConversation loads the conversation in the :id path parameter if the user takes part in it
+func findMyConversation(c echo.Context, uid string) (models.Conversation, error) {
+	var conversation models.Conversation
+	err := config.DB.Where("id = ? AND (user_a = ? OR user_b = ?)", c.Param("id"), uid, uid).First(&conversation).Error
+	return conversation, err
+}
+
+// loadParticipants returns the public details of users shown in conversations, keyed by UID
+func loadParticipants(uids []string) map[string]echo.Map {
+	participants := make(map[string]echo.Map, len(uids))
+	if len(uids) == 0 {
+		return participants
+	}
+
+	var users []models.User
+	config.DB.Select("uid, name, type").Where("uid IN ?", uids).Find(&users)
+	for _, user := range users {
+		participants[user.Uid] = echo.Map{"uid": user.Uid, "name": user.Name, "type": user.Type}
+	}
+	return participants
+}
+
+// GetMyConversations returns a page of the user's conversations, most recent first, with the other
+// participant, the last message and the unread count of each
+func GetMyConversations(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+	uid := userData.GetUID()
+
+	// Get pagination parameters
+	page := 1
+	pageSize := 20
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if pageSizeParam := c.QueryParam("pageSize"); pageSizeParam != "" {
+		if ps, err := strconv.Atoi(pageSizeParam); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+
+	query := config.DB.Model(&models.Conversation{}).Where("user_a = ? OR user_b = ?", uid, uid)
+
+	// Get total count before pagination
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to count conversations"})
+	}
+
+	// Apply pagination
+	var conversations []models.Conversation
+	offset := (page - 1) * pageSize
+	if err := query.Offset(offset).Limit(pageSize).Order("last_message_at DESC").Find(&conversations).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch conversations"})
+	}
+
+	conversationIDs := make([]uint, 0, len(conversations))
+	otherUIDs := make([]string, 0, len(conversations))
+	for _, conversation := range conversations {
+		conversationIDs = append(conversationIDs, conversation.ID)
+		otherUIDs = append(otherUIDs, conversation.OtherParticipant(uid))
+	}
+	participants := loadParticipants(otherUIDs)
+
+	lastMessages := make(map[uint]models.DirectMessage, len(conversations))
+	unreadCounts := make(map[uint]int64, len(conversations))
+	if len(conversationIDs) > 0 {
+		var messages []models.DirectMessage
+		config.DB.Raw("SELECT DISTINCT ON (conversation_id) * FROM direct_messages WHERE conversation_id IN ? ORDER BY conversation_id, created_at DESC, id DESC", conversationIDs).Scan(&messages)
+		for _, message := range messages {
+			lastMessages[message.ConversationID] = message
+		}
+
+		var counts []struct {
+			ConversationID uint
+			Count          int64
+		}
+		config.DB.Model(&models.DirectMessage{}).
+			Select("conversation_id, COUNT(*) AS count").
+			Where("conversation_id IN ? AND recipient_uid = ? AND read_at IS NULL", conversationIDs, uid).
+			Group("conversation_id").
+			Scan(&counts)
+		for _, count := range counts {
+			unreadCounts[count.ConversationID] = count.Count
+		}
+	}
+
+	results := make([]echo.Map, 0, len(conversations))
+	for _, conversation := range conversations {
+		var lastMessage *models.DirectMessage
+		if message, ok := lastMessages[conversation.ID]; ok {
+			lastMessage = &message
+		}
+		results = append(results, echo.Map{
+			"id":            conversation.ID,
+			"participant":   participants[conversation.OtherParticipant(uid)],
+			"lastMessage":   lastMessage,
+			"lastMessageAt": conversation.LastMessageAt,
+			"unread":        unreadCounts[conversation.ID],
+		})
+	}
+
+	totalPages := int((totalCount + int64(pageSize) - 1) / int64(pageSize))
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"conversations": results,
+		"count":         len(results),
+		"total":         totalCount,
+		"page":          page,
+		"pageSize":      pageSize,
+		"totalPages":    totalPages,
+	})
+}
+
+// StartConversation sends a message to a user, opening a conversation with them if there is none yet
+func StartConversation(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Parse request body
+	var requestBody struct {
+		RecipientUID string   `json:"recipientUid"`
+		Body         string   `json:"body"`
+		Attachments  []string `json:"attachments"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if requestBody.RecipientUID == "" || requestBody.RecipientUID == userData.GetUID() {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "A recipient other than yourself is required"})
+	}
+
+	body, attachments, msg := validateDirectMessage(requestBody.Body, requestBody.Attachments)
+	if msg != "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": msg})
+	}
+
+	var sender, recipient models.User
+	if err := config.DB.Where("uid = ?", userData.GetUID()).First(&sender).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "User not found"})
+	}
+	if err := config.DB.Where("uid = ?", requestBody.RecipientUID).First(&recipient).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Recipient not found"})
+	}
+
+	rules := utils.LoadMessagingRules()
+	userA, userB := conversationPair(sender.Uid, recipient.Uid)
+
+	var conversation models.Conversation
+	if err := config.DB.Where("user_a = ? AND user_b = ?", userA, userB).First(&conversation).Error; err != nil {
+		// The contact rules only apply to new conversations; either side may always reply
+		if msg := checkConversationStart(sender, recipient, rules); msg != "" {
+			return c.JSON(http.StatusForbidden, echo.Map{"error": msg})
+		}
+
+		conversation = models.Conversation{UserA: userA, UserB: userB, LastMessageAt: time.Now()}
+		if err := config.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&conversation).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to start conversation"})
+		}
+		// Another request may have opened the same conversation first
+		if err := config.DB.Where("user_a = ? AND user_b = ?", userA, userB).First(&conversation).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to start conversation"})
+		}
+	}
+
+	if msg := checkSendLimits(sender.Uid, conversation, rules); msg != "" {
+		return c.JSON(http.StatusTooManyRequests, echo.Map{"error": msg})
+	}
+
+	message, err := sendDirectMessage(conversation, sender, body, attachments)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to send message"})
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message":      "Message sent",
+		"conversation": conversation,
+		"sent":         message,
+	})
+}
+
+// GetConversationMessages returns a page of a conversation's messages, newest first, and marks the
+// messages sent to the user as read
+func GetConversationMessages(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	conversation, err := findMyConversation(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Conversation not found"})
+	}
+
+	// Get pagination parameters
+	page := 1
+	pageSize := 50
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if pageSizeParam := c.QueryParam("pageSize"); pageSizeParam != "" {
+		if ps, err := strconv.Atoi(pageSizeParam); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+
+	if err := markConversationRead(conversation.ID, userData.GetUID()); err != nil {
+		log.Printf("Failed to mark conversation %d as read: %v", conversation.ID, err)
+	}
+
+	query := config.DB.Model(&models.DirectMessage{}).Where("conversation_id = ?", conversation.ID)
+
+	// Get total count before pagination
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to count messages"})
+	}
+
+	// Apply pagination
+	messages := []models.DirectMessage{}
+	offset := (page - 1) * pageSize
+	if err := query.Offset(offset).Limit(pageSize).Order("created_at DESC, id DESC").Find(&messages).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch messages"})
+	}
+
+	totalPages := int((totalCount + int64(pageSize) - 1) / int64(pageSize))
+	otherUID := conversation.OtherParticipant(userData.GetUID())
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"conversationId": conversation.ID,
+		"participant":    loadParticipants([]string{otherUID})[otherUID],
+		"messages":       messages,
+		"count":          len(messages),
+		"total":          totalCount,
+		"page":           page,
+		"pageSize":       pageSize,
+		"totalPages":     totalPages,
+	})
+}
+
+// SendConversationMessage sends a message in an existing conversation
+func SendConversationMessage(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	conversation, err := findMyConversation(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Conversation not found"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Body        string   `json:"body"`
+		Attachments []string `json:"attachments"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	body, attachments, msg := validateDirectMessage(requestBody.Body, requestBody.Attachments)
+	if msg != "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": msg})
+	}
+
+	var sender models.User
+	if err := config.DB.Where("uid = ?", userData.GetUID()).First(&sender).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "User not found"})
+	}
+
+	if msg := checkSendLimits(sender.Uid, conversation, utils.LoadMessagingRules()); msg != "" {
+		return c.JSON(http.StatusTooManyRequests, echo.Map{"error": msg})
+	}
+
+	// Replying means the user has seen the conversation
+	if err := markConversationRead(conversation.ID, sender.Uid); err != nil {
+		log.Printf("Failed to mark conversation %d as read: %v", conversation.ID, err)
+	}
+
+	message, err := sendDirectMessage(conversation, sender, body, attachments)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to send message"})
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Message sent",
+		"sent":    message,
+	})
+}
+
+// MarkConversationRead marks every message the user received in a conversation as read
+func MarkConversationRead(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	conversation, err := findMyConversation(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Conversation not found"})
+	}
+
+	if err := markConversationRead(conversation.ID, userData.GetUID()); err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to mark conversation as read"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "Conversation marked as read"})
+}
+
+// GetUnreadMessageCount returns how many direct messages the user has not read, for the inbox badge
+func GetUnreadMessageCount(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	var unreadCount int64
+	if err := config.DB.Model(&models.DirectMessage{}).Where("recipient_uid = ? AND read_at IS NULL", userData.GetUID()).Count(&unreadCount).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to count unread messages"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"unread": unreadCount})
+}
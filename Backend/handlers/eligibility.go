@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// degreeTokens splits a degree name into lowercase words, dropping punctuation such as the dots in "B.Sc."
+func degreeTokens(degree string) []string {
+	return strings.FieldsFunc(strings.ToLower(degree), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// matchesDegree reports whether the allowed degree appears as whole consecutive words of the student's degree,
+// so "MSc Physics" matches "MSc" but "MBA" does not match "BA"
+func matchesDegree(degree, allowed []string) bool {
+	if len(allowed) == 0 {
+		return false
+	}
+	for start := 0; start+len(allowed) <= len(degree); start++ {
+		if slices.Equal(degree[start:start+len(allowed)], allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// profileSectionChecks maps the profile sections a project can require to a completeness check
+var profileSectionChecks = map[string]func(models.Students) bool{
+	"resume": func(s models.Students) bool {
+		return strings.TrimSpace(s.Resume) != ""
+	},
+	"education": func(s models.Students) bool {
+		return len(s.EducationDetails) > 0 || strings.TrimSpace(s.Institution) != ""
+	},
+	"experience": func(s models.Students) bool {
+		return len(s.ExperienceDetails) > 0 || strings.TrimSpace(s.Experience) != ""
+	},
+	"projects": func(s models.Students) bool {
+		return len(s.ProjectsDetails) > 0 || len(s.Projects) > 0
+	},
+	"publications": func(s models.Students) bool {
+		return len(s.PublicationsList) > 0 || strings.TrimSpace(s.Publications) != ""
+	},
+	"skills": func(s models.Students) bool {
+		return len(s.Skills) > 0
+	},
+	"summary": func(s models.Students) bool {
+		return strings.TrimSpace(s.Summary) != ""
+	},
+	"researchInterest": func(s models.Students) bool {
+		return strings.TrimSpace(s.ResearchInterest) != ""
+	},
+}
+
+// eligibilityProfile is the part of a student's data eligibility rules are evaluated against
+type eligibilityProfile struct {
+	Student     models.Students
+	HasProfile  bool
+	CurrentYear int // From research preferences; 0 when unknown
+}
+
+// loadEligibilityProfile loads the student profile and current year of study
+func loadEligibilityProfile(db *gorm.DB, uid string) eligibilityProfile {
+	var profile eligibilityProfile
+	profile.HasProfile = db.Where("uid = ?", uid).First(&profile.Student).Error == nil
+
+	var preferences models.ResearchPreference
+	if db.Where("user_id = ?", uid).First(&preferences).Error == nil {
+		profile.CurrentYear = preferences.CurrentYear
+	}
+	return profile
+}
+
+// hasEligibilityRules reports whether a project restricts who may apply
+func hasEligibilityRules(project models.Projects) bool {
+	return project.MinYear > 0 || len(project.AllowedDegrees) > 0 || len(project.RequiredSkills) > 0 || len(project.RequiredProfileSections) > 0
+}
+
+// evaluateEligibility returns the reasons a student does not meet a project's rules; empty means eligible
+func evaluateEligibility(project models.Projects, profile eligibilityProfile) []string {
+	reasons := []string{}
+	if !hasEligibilityRules(project) {
+		return reasons
+	}
+
+	if !profile.HasProfile {
+		return append(reasons, "Complete your student profile before applying to this project")
+	}
+	student := profile.Student
+
+	if project.MinYear > 0 {
+		if profile.CurrentYear == 0 {
+			reasons = append(reasons, fmt.Sprintf("This project requires year %d or above; add your current year in your research preferences", project.MinYear))
+		} else if profile.CurrentYear < project.MinYear {
+			reasons = append(reasons, fmt.Sprintf("This project requires year %d or above; you are in year %d", project.MinYear, profile.CurrentYear))
+		}
+	}
+
+	if len(project.AllowedDegrees) > 0 {
+		degree := degreeTokens(student.Degree)
+		allowed := false
+		for _, candidate := range project.AllowedDegrees {
+			if matchesDegree(degree, degreeTokens(candidate)) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			reasons = append(reasons, fmt.Sprintf("This project is open to %s students only", strings.Join(project.AllowedDegrees, ", ")))
+		}
+	}
+
+	if len(project.RequiredSkills) > 0 {
+		have := make(map[string]bool, len(student.Skills))
+		for _, skill := range student.Skills {
+			have[normalizeString(skill)] = true
+		}
+		var missing []string
+		for _, skill := range project.RequiredSkills {
+			if !have[normalizeString(skill)] {
+				missing = append(missing, skill)
+			}
+		}
+		if len(missing) > 0 {
+			reasons = append(reasons, fmt.Sprintf("Missing required skills: %s", strings.Join(missing, ", ")))
+		}
+	}
+
+	var incomplete []string
+	for _, section := range project.RequiredProfileSections {
+		if check, ok := profileSectionChecks[section]; ok && !check(student) {
+			incomplete = append(incomplete, section)
+		}
+	}
+	if len(incomplete) > 0 {
+		reasons = append(reasons, fmt.Sprintf("Complete these profile sections first: %s", strings.Join(incomplete, ", ")))
+	}
+
+	return reasons
+}
+
+// validateProfileSections reports the first unknown section name, if any
+func validateProfileSections(sections []string) (string, bool) {
+	for _, section := range sections {
+		if _, ok := profileSectionChecks[section]; !ok {
+			return section, false
+		}
+	}
+	return "", true
+}
+
+// GetProjectEligibility tells a student whether they meet a project's eligibility rules
+func GetProjectEligibility(c echo.Context) error {
+	projectID := c.Param("id")
+	if projectID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Project ID is required"})
+	}
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can check eligibility"})
+	}
+
+	var project models.Projects
+	if err := config.DB.Where("project_id = ?", projectID).First(&project).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	}
+
+	reasons := evaluateEligibility(project, loadEligibilityProfile(config.DB, userData.GetUID()))
+	return c.JSON(http.StatusOK, echo.Map{
+		"eligible": len(reasons) == 0,
+		"reasons":  reasons,
+	})
+}
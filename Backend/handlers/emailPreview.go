@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"backend/utils"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// emailPreviewSamples holds representative data for previewing each email template
+var emailPreviewSamples = map[string]echo.Map{
+	"verify_email_link":       {"VerificationURL": "https://example.com/verify-email?token=sample-token"},
+	"verification_code":       {"Name": "Alex Doe", "Code": "123456", "Welcome": true},
+	"password_reset":          {"ResetURL": "https://example.com/reset-password?token=sample-token"},
+	"password_reset_success":  {"Name": "Alex Doe"},
+	"welcome":                 {"Name": "Alex Doe"},
+	"application_received":    {"ProjectName": "Coral Reef Imaging", "ApplicantName": "Alex Doe"},
+	"status_update":           {"StudentName": "Alex Doe", "ProjectName": "Coral Reef Imaging", "Status": "accepted", "StatusLabel": "accepted"},
+	"feedback":                {"StudentName": "Alex Doe", "ProfessorName": "Dr. Sam Lee", "ProjectName": "Coral Reef Imaging", "Feedback": "Strong application.\nPlease share a writing sample."},
+	"interview_scheduled":     {"StudentName": "Alex Doe", "ProfessorName": "Dr. Sam Lee", "ProjectName": "Coral Reef Imaging", "Date": "Mon, Jun 2, 2025", "Time": "10:00 - 10:30 UTC", "Details": "Room 204, Science Building"},
+	"interview_invite":        {"StudentName": "Alex Doe", "ProfessorName": "Dr. Sam Lee", "ProjectName": "Coral Reef Imaging", "Details": "30 minutes over video call"},
+	"interview_booking":       {"ProfessorName": "Dr. Sam Lee", "StudentName": "Alex Doe", "ProjectName": "Coral Reef Imaging", "Action": "booked", "Slot": "Mon, Jun 2, 2025, 10:00 - 10:30 UTC"},
+	"thread_reply":            {"ProfessorName": "Dr. Sam Lee", "StudentName": "Alex Doe", "ProjectName": "Coral Reef Imaging", "Reply": "Thank you! I have attached a sample to my profile."},
+	"recommendation_request":  {"RefereeName": "Dr. Sam Lee", "StudentName": "Alex Doe", "ProjectName": "Coral Reef Imaging", "Note": "I took your Marine Biology course last spring.", "UploadURL": "https://example.com/recommendation-letters/sample-token", "Reminder": false},
+	"recommendation_response": {"StudentName": "Alex Doe", "RefereeName": "Dr. Sam Lee", "ProjectName": "Coral Reef Imaging", "Status": "submitted"},
+	"notification_digest": {"Name": "Dr. Sam Lee", "Weekly": false, "Entries": []digestEmailEntry{
+		{Summary: "Alex Doe applied to Coral Reef Imaging.", Time: time.Now().UTC().Add(-3 * time.Hour).Format("Jan 2, 15:04 MST")},
+		{Summary: "Jordan Kim applied to Coral Reef Imaging.", Time: time.Now().UTC().Add(-time.Hour).Format("Jan 2, 15:04 MST")},
+	}},
+	"direct_message":       {"RecipientName": "Dr. Sam Lee", "SenderName": "Alex Doe", "Body": "Is the Coral Reef Imaging project open to second-year students?", "Attachments": []string{"https://example.com/alex-doe-cv.pdf"}, "ConversationURL": "https://example.com/messages/1"},
+	"project_announcement": {"Name": "Alex Doe", "AuthorName": "Dr. Sam Lee", "ProjectName": "Coral Reef Imaging", "Title": "Lab meeting moved to Thursday", "Body": "This week's lab meeting is on Thursday at 10:00 in room B204.", "RequireAck": true, "AnnouncementsURL": "https://example.com/project/sample-project/announcements"},
+	"project_completion":   {"Name": "Alex Doe", "SupervisorName": "Dr. Sam Lee", "ProjectName": "Coral Reef Imaging", "Evaluation": "Alex was reliable, curious and wrote excellent documentation for the imaging pipeline.", "CertificateCode": "ABCD-EFGH-2345-6789", "VerifyURL": "https://example.com/certificates/ABCD-EFGH-2345-6789", "CompletionsURL": "https://example.com/student/completions"},
+	"project_matches": {"Name": "Alex Doe", "Matches": []projectMatchEmailEntry{
+		{Name: "Coral Reef Imaging", Professor: "Dr. Sam Lee", Score: 82, Reasons: []string{"Matches your research interests", "Fits your preferred time commitment"}, URL: "https://example.com/project/sample-project"},
+	}, "ProjectsURL": "https://example.com/student/recommendations", "UnsubscribeURL": "https://example.com/unsubscribe?token=sample-token"},
+}
+
+// GetEmailTemplates lists the email templates and the locales they can be rendered in
+func GetEmailTemplates(c echo.Context) error {
+	return c.JSON(http.StatusOK, echo.Map{
+		"templates":     utils.EmailTemplateNames(),
+		"locales":       utils.EmailLocales(),
+		"defaultLocale": utils.DefaultEmailLocale,
+	})
+}
+
+// PreviewEmailTemplate renders an email template with sample data.
+// POST requests may send a JSON object whose fields override the sample data.
+// ?locale= picks the language; ?format=html or ?format=text returns that body alone instead of JSON.
+func PreviewEmailTemplate(c echo.Context) error {
+	name := c.Param("name")
+	sample, ok := emailPreviewSamples[name]
+	if !ok {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Email template not found"})
+	}
+
+	data := make(echo.Map, len(sample))
+	for key, value := range sample {
+		data[key] = value
+	}
+	if c.Request().Method == http.MethodPost {
+		var overrides echo.Map
+		if err := c.Bind(&overrides); err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+		}
+		for key, value := range overrides {
+			data[key] = value
+		}
+	}
+
+	locale := c.QueryParam("locale")
+	if locale == "" {
+		locale = utils.DefaultEmailLocale
+	} else if !utils.IsSupportedEmailLocale(locale) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Unsupported locale"})
+	}
+
+	content, err := utils.RenderEmail(name, locale, data)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	switch c.QueryParam("format") {
+	case "html":
+		return c.HTML(http.StatusOK, content.HTML)
+	case "text":
+		return c.String(http.StatusOK, content.Text)
+	default:
+		return c.JSON(http.StatusOK, echo.Map{
+			"template": name,
+			"locale":   locale,
+			"subject":  content.Subject,
+			"html":     content.HTML,
+			"text":     content.Text,
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// eventHeartbeatInterval keeps idle streams alive through proxies that close silent connections
+const eventHeartbeatInterval = 25 * time.Second
+
+// publishEvent pushes an event to the user's real-time streams
+func publishEvent(uid, eventType string, data interface{}) {
+	utils.GetEventBroker().Publish(uid, utils.Event{Type: eventType, Data: data})
+}
+
+// writeEvent writes one Server-Sent Event and flushes it to the client
+func writeEvent(res *echo.Response, event utils.Event) error {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.Type, payload); err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
+}
+
+// IssueStreamToken returns a short-lived token that only opens the event stream, so the session JWT never
+// ends up in URLs and access logs. Clients fetch a fresh one whenever they (re)connect.
+func IssueStreamToken(c echo.Context) error {
+	claims := c.Get("claims").(*utils.JWTClaims)
+
+	token, expiresAt, err := utils.GenerateStreamToken(claims)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to generate stream token"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"token":     token,
+		"expiresAt": expiresAt,
+	})
+}
+
+// StreamEvents keeps a Server-Sent Events stream open and pushes the user's real-time events to it
+func StreamEvents(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+	uid := userData.GetUID()
+
+	events, unsubscribe := utils.GetEventBroker().Subscribe(uid)
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+	res.WriteHeader(http.StatusOK)
+
+	// Tell the client how long to wait before reconnecting, and give it the current unread counts
+	if _, err := fmt.Fprint(res, "retry: 5000\n\n"); err != nil {
+		return nil
+	}
+	var unreadCount int64
+	config.DB.Model(&models.Notification{}).Where("recipient_uid = ? AND read_at IS NULL", uid).Count(&unreadCount)
+	var unreadMessages int64
+	config.DB.Model(&models.DirectMessage{}).Where("recipient_uid = ? AND read_at IS NULL", uid).Count(&unreadMessages)
+	if err := writeEvent(res, utils.Event{Type: "ready", Data: echo.Map{"unread": unreadCount, "unreadMessages": unreadMessages}}); err != nil {
+		return nil
+	}
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := writeEvent(res, event); err != nil {
+				log.Printf("Failed to write %s event to user %s: %v", event.Type, uid, err)
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,645 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	minSlotMinutes    = 10
+	maxSlotMinutes    = 240
+	maxWindowHours    = 24
+	slotLookaheadDays = 60
+)
+
+// InterviewSlot is a bookable interview slot shown to an invited applicant
+type InterviewSlot struct {
+	StartsAt   time.Time `json:"startsAt"`
+	EndsAt     time.Time `json:"endsAt"`
+	LocalStart string    `json:"localStart"`
+	LocalEnd   string    `json:"localEnd"`
+	Timezone   string    `json:"timezone"`
+}
+
+// loadLocation resolves an IANA timezone name, defaulting to UTC when empty
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// parseWindowTime accepts an RFC3339 timestamp or a local "2006-01-02T15:04" time in the given zone
+func parseWindowTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02T15:04", value, loc)
+}
+
+// formatInterviewSlot fills the legacy date/time strings of an application from a booked slot
+func formatInterviewSlot(start, end time.Time, loc *time.Location) (date string, clock string) {
+	start, end = start.In(loc), end.In(loc)
+	return start.Format("Mon, 02 Jan 2006"), fmt.Sprintf("%s - %s %s", start.Format("15:04"), end.Format("15:04"), start.Format("MST"))
+}
+
+// loadProfessorBookings returns interviews booked with a professor across all their projects that overlap the range
+func loadProfessorBookings(db *gorm.DB, professorUID string, from, to time.Time, excludeID uint) ([]models.ProjRequests, error) {
+	var bookings []models.ProjRequests
+	err := db.Joins("JOIN projects ON projects.project_id = proj_requests.p_id AND projects.deleted_at IS NULL").
+		Where("projects.creator_id = ? AND proj_requests.status = ? AND proj_requests.id != ?", professorUID, "interview", excludeID).
+		Where("proj_requests.interview_starts_at < ? AND proj_requests.interview_ends_at > ?", to, from).
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// overlapsBooking reports whether the range collides with any booked interview
+func overlapsBooking(start, end time.Time, bookings []models.ProjRequests) bool {
+	for _, booking := range bookings {
+		if booking.InterviewStartsAt == nil || booking.InterviewEndsAt == nil {
+			continue
+		}
+		if start.Before(*booking.InterviewEndsAt) && end.After(*booking.InterviewStartsAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateOpenSlots splits availability windows into slots and drops past or already booked ones
+func generateOpenSlots(windows []models.AvailabilityWindow, bookings []models.ProjRequests, now time.Time, loc *time.Location) []InterviewSlot {
+	slots := []InterviewSlot{}
+	for _, window := range windows {
+		length := time.Duration(window.SlotMinutes) * time.Minute
+		for start := window.StartsAt; !start.Add(length).After(window.EndsAt); start = start.Add(length) {
+			end := start.Add(length)
+			if !start.After(now) || overlapsBooking(start, end, bookings) {
+				continue
+			}
+			slots = append(slots, InterviewSlot{
+				StartsAt:   start.UTC(),
+				EndsAt:     end.UTC(),
+				LocalStart: start.In(loc).Format("2006-01-02T15:04"),
+				LocalEnd:   end.In(loc).Format("2006-01-02T15:04"),
+				Timezone:   loc.String(),
+			})
+		}
+	}
+	return slots
+}
+
+// findSlotWindow returns the availability window the slot start lines up with
+func findSlotWindow(db *gorm.DB, professorUID string, start time.Time) (models.AvailabilityWindow, bool) {
+	var windows []models.AvailabilityWindow
+	db.Where("professor_uid = ? AND starts_at <= ? AND ends_at > ?", professorUID, start, start).Find(&windows)
+
+	for _, window := range windows {
+		length := time.Duration(window.SlotMinutes) * time.Minute
+		offset := start.Sub(window.StartsAt)
+		if offset%length == 0 && !start.Add(length).After(window.EndsAt) {
+			return window, true
+		}
+	}
+	return models.AvailabilityWindow{}, false
+}
+
+// releaseInterviewSlot records in updates that the slot booked on an application is given up, returning
+// the released booking so its invite can be withdrawn; nil when no slot was booked
+func releaseInterviewSlot(application models.ProjRequests, updates map[string]interface{}) *models.ProjRequests {
+	if application.InterviewStartsAt == nil {
+		return nil
+	}
+	updates["interview_cancelled_at"] = time.Now()
+	released := application
+	return &released
+}
+
+// releaseInterviewForStatus releases an upcoming booked slot when an application leaves the interview stage,
+// returning the released booking whose invite must be withdrawn; nil when there is none
+func releaseInterviewForStatus(application models.ProjRequests, status string, updates map[string]interface{}) *models.ProjRequests {
+	if status == "interview" || !hasUpcomingInterview(application) {
+		return nil
+	}
+	updates["interview_starts_at"] = nil
+	updates["interview_ends_at"] = nil
+	return releaseInterviewSlot(application, updates)
+}
+
+// hasUpcomingInterview reports whether an application holds a booked slot that has not started yet
+func hasUpcomingInterview(application models.ProjRequests) bool {
+	return application.Status == "interview" && application.InterviewStartsAt != nil && application.InterviewStartsAt.After(time.Now())
+}
+
+// interviewCancellationInvite withdraws the calendar invite sent when a slot was booked
+func interviewCancellationInvite(released models.ProjRequests, projectName, professorEmail, studentEmail string) utils.EmailAttachment {
+	return utils.CalendarAttachment(utils.CalendarMethodCancel, []utils.CalendarEvent{
+		interviewCalendarEvent(released, projectName, professorEmail, studentEmail, true),
+	})
+}
+
+// CreateAvailabilityWindow publishes a window of interview availability for the professor
+func CreateAvailabilityWindow(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a faculty member
+	if userData.GetUserType() != models.UserTypeFaculty {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can publish availability"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		StartsAt    string `json:"startsAt"`
+		EndsAt      string `json:"endsAt"`
+		SlotMinutes int    `json:"slotMinutes"`
+		Timezone    string `json:"timezone"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	loc, err := loadLocation(requestBody.Timezone)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid timezone"})
+	}
+
+	startsAt, err := parseWindowTime(requestBody.StartsAt, loc)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid start time"})
+	}
+	endsAt, err := parseWindowTime(requestBody.EndsAt, loc)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid end time"})
+	}
+
+	if requestBody.SlotMinutes == 0 {
+		requestBody.SlotMinutes = 30
+	}
+	if requestBody.SlotMinutes < minSlotMinutes || requestBody.SlotMinutes > maxSlotMinutes {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Slot length must be between %d and %d minutes", minSlotMinutes, maxSlotMinutes)})
+	}
+
+	if !endsAt.After(startsAt) || !endsAt.After(time.Now()) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Window must end after it starts and in the future"})
+	}
+	if endsAt.Sub(startsAt) > maxWindowHours*time.Hour {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Windows cannot be longer than %d hours", maxWindowHours)})
+	}
+	if endsAt.Sub(startsAt) < time.Duration(requestBody.SlotMinutes)*time.Minute {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Window is shorter than a single slot"})
+	}
+
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Reject windows overlapping an existing one so slots never double up
+	var overlapping int64
+	tx.Model(&models.AvailabilityWindow{}).
+		Where("professor_uid = ? AND starts_at < ? AND ends_at > ?", userData.GetUID(), endsAt, startsAt).
+		Count(&overlapping)
+	if overlapping > 0 {
+		tx.Rollback()
+		return c.JSON(http.StatusConflict, echo.Map{"error": "Window overlaps an existing availability window"})
+	}
+
+	window := models.AvailabilityWindow{
+		ProfessorUID: userData.GetUID(),
+		StartsAt:     startsAt.UTC(),
+		EndsAt:       endsAt.UTC(),
+		SlotMinutes:  requestBody.SlotMinutes,
+		Timezone:     loc.String(),
+	}
+
+	if err := tx.Create(&window).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save availability"})
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Availability published successfully",
+		"window":  window,
+	})
+}
+
+// GetMyAvailability returns the professor's upcoming availability windows and booked interviews
+func GetMyAvailability(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a faculty member
+	if userData.GetUserType() != models.UserTypeFaculty {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can view availability"})
+	}
+
+	now := time.Now()
+
+	var windows []models.AvailabilityWindow
+	if err := config.DB.Where("professor_uid = ? AND ends_at > ?", userData.GetUID(), now).Order("starts_at ASC").Find(&windows).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch availability"})
+	}
+
+	bookings, err := loadProfessorBookings(config.DB, userData.GetUID(), now, now.AddDate(1, 0, 0), 0)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch booked interviews"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"windows":  windows,
+		"bookings": bookings,
+	})
+}
+
+// DeleteAvailabilityWindow removes an availability window; interviews already booked in it are kept
+func DeleteAvailabilityWindow(c echo.Context) error {
+	windowID := c.Param("windowId")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a faculty member
+	if userData.GetUserType() != models.UserTypeFaculty {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can manage availability"})
+	}
+
+	result := config.DB.Where("id = ? AND professor_uid = ?", windowID, userData.GetUID()).Delete(&models.AvailabilityWindow{})
+	if result.Error != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to delete availability"})
+	}
+	if result.RowsAffected == 0 {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Availability window not found"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "Availability window deleted successfully"})
+}
+
+// GetInterviewSlots lists the open interview slots an invited applicant can book
+func GetInterviewSlots(c echo.Context) error {
+	applicationID := c.Param("appId")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can book interview slots"})
+	}
+
+	loc, err := loadLocation(c.QueryParam("timezone"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid timezone"})
+	}
+
+	var application models.ProjRequests
+	if err := config.DB.Where("id = ? AND uid = ?", applicationID, userData.GetUID()).First(&application).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Application not found"})
+	}
+
+	if application.Status != "interview" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "You have not been invited to interview for this project"})
+	}
+
+	var project models.Projects
+	if err := config.DB.Where("project_id = ?", application.PID).First(&project).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	}
+
+	now := time.Now()
+	until := now.AddDate(0, 0, slotLookaheadDays)
+
+	var windows []models.AvailabilityWindow
+	if err := config.DB.Where("professor_uid = ? AND ends_at > ? AND starts_at < ?", project.CreatorID, now, until).Order("starts_at ASC").Find(&windows).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch availability"})
+	}
+
+	bookings, err := loadProfessorBookings(config.DB, project.CreatorID, now, until, application.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch booked interviews"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"applicationId": application.ID,
+		"booked":        application.InterviewStartsAt != nil,
+		"current":       application.InterviewStartsAt,
+		"slots":         generateOpenSlots(windows, bookings, now, loc),
+	})
+}
+
+// BookInterviewSlot books or reschedules the interview of an invited applicant
+func BookInterviewSlot(c echo.Context) error {
+	applicationID := c.Param("appId")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can book interview slots"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		StartsAt string `json:"startsAt"`
+		Timezone string `json:"timezone"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	startsAt, err := time.Parse(time.RFC3339, requestBody.StartsAt)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Slot start must be an RFC3339 timestamp"})
+	}
+	if !startsAt.After(time.Now()) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Slot has already started"})
+	}
+
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var application models.ProjRequests
+	if err := tx.Where("id = ? AND uid = ?", applicationID, userData.GetUID()).First(&application).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Application not found"})
+	}
+
+	if application.Status != "interview" {
+		tx.Rollback()
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "You have not been invited to interview for this project"})
+	}
+
+	var project models.Projects
+	if err := tx.Where("project_id = ?", application.PID).First(&project).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	}
+
+	// Lock the professor row so concurrent bookings across their projects are serialized
+	var professor models.User
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uid = ?", project.CreatorID).First(&professor).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Professor not found"})
+	}
+
+	window, ok := findSlotWindow(tx, project.CreatorID, startsAt)
+	if !ok {
+		tx.Rollback()
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Selected time is not an available slot"})
+	}
+	endsAt := startsAt.Add(time.Duration(window.SlotMinutes) * time.Minute)
+
+	bookings, err := loadProfessorBookings(tx, project.CreatorID, startsAt, endsAt, application.ID)
+	if err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to check availability"})
+	}
+	if len(bookings) > 0 {
+		tx.Rollback()
+		return c.JSON(http.StatusConflict, echo.Map{"error": "This slot has just been booked, please pick another"})
+	}
+
+	// Students cannot be in two interviews at once either
+	var ownConflicts int64
+	if err := tx.Model(&models.ProjRequests{}).
+		Where("uid = ? AND id != ? AND status = ? AND interview_starts_at < ? AND interview_ends_at > ?", userData.GetUID(), application.ID, "interview", endsAt, startsAt).
+		Count(&ownConflicts).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to check availability"})
+	}
+	if ownConflicts > 0 {
+		tx.Rollback()
+		return c.JSON(http.StatusConflict, echo.Map{"error": "You already have another interview at this time"})
+	}
+
+	timezone := requestBody.Timezone
+	if timezone == "" {
+		timezone = window.Timezone
+	}
+	loc, err := loadLocation(timezone)
+	if err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid timezone"})
+	}
+
+	action := "booked"
+	if application.InterviewStartsAt != nil {
+		action = "rescheduled"
+	}
+
+	now := time.Now()
+	startsAt, endsAt = startsAt.UTC(), endsAt.UTC()
+	interviewDate, interviewTime := formatInterviewSlot(startsAt, endsAt, loc)
+	updates := map[string]interface{}{
+		"interview_starts_at":    startsAt,
+		"interview_ends_at":      endsAt,
+		"interview_timezone":     loc.String(),
+		"interview_booked_at":    now,
+		"interview_cancelled_at": nil,
+		"interview_date":         interviewDate,
+		"interview_time":         interviewTime,
+	}
+
+	if err := tx.Model(&application).Updates(updates).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to book interview"})
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
+	}
+
+	// Confirm the slot to the student and let the professor know
+	go func() {
+		var student models.User
+		if err := config.DB.Where("uid = ?", application.UID).First(&student).Error; err != nil {
+			log.Printf("Failed to fetch student for booking email: %v", err)
+			return
+		}
+
+		professorLoc, err := loadLocation(window.Timezone)
+		if err != nil {
+			professorLoc = time.UTC
+		}
+		professorDate, professorTime := formatInterviewSlot(startsAt, endsAt, professorLoc)
+
+		createNotifications([]models.Notification{applicationNotification(professor.Uid, models.NotificationInterviewScheduled,
+			fmt.Sprintf("Interview %s for %s", action, project.Name),
+			fmt.Sprintf("%s has %s their interview for %s: %s, %s.", student.Name, action, project.Name, professorDate, professorTime),
+			application)})
+
+		// Both sides get a calendar invite; a reschedule reuses the event UID so it moves in place
+		booked := application
+		booked.InterviewStartsAt, booked.InterviewEndsAt = &startsAt, &endsAt
+		invite := utils.CalendarAttachment(utils.CalendarMethodRequest, []utils.CalendarEvent{
+			interviewCalendarEvent(booked, project.Name, professor.Email, student.Email, false),
+		})
+
+		locales := loadEmailLocales([]string{student.Uid, professor.Uid})
+		if emailMessage, err := buildInterviewEmail(student.Email, locales[student.Uid], student.Name, professor.Name, project.Name, interviewDate, interviewTime, application.InterviewDetails); err != nil {
+			log.Printf("Failed to build booking email to student %s: %v", student.Email, err)
+		} else {
+			emailMessage.Attachments = []utils.EmailAttachment{invite}
+			if err := utils.SendEmail(utils.LoadEmailConfig(), emailMessage); err != nil {
+				log.Printf("Failed to send booking email to student %s: %v", student.Email, err)
+			}
+		}
+
+		// The student asked for their own confirmation above; the professor's follows their preferences
+		emailMessage, err := buildInterviewBookingEmail(professor.Email, locales[professor.Uid], professor.Name, student.Name, project.Name, action, professorDate+", "+professorTime)
+		if err != nil {
+			log.Printf("Failed to build booking email to professor %s: %v", professor.Email, err)
+			return
+		}
+		emailMessage.Attachments = []utils.EmailAttachment{invite}
+		dispatchEmail(models.NotificationInterviewScheduled, professor.Uid, emailMessage,
+			fmt.Sprintf("%s has %s their interview for %s: %s, %s.", student.Name, action, project.Name, professorDate, professorTime))
+	}()
+
+	// Fetch updated application
+	if err := config.DB.Where("id = ?", application.ID).First(&application).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch updated application"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":     fmt.Sprintf("Interview %s successfully", action),
+		"application": application,
+	})
+}
+
+// sendInterviewCancellationToProfessor tells the professor that an applicant gave up their booked slot and
+// withdraws its calendar invite; cancelled must still carry the released slot. It blocks on SMTP.
+func sendInterviewCancellationToProfessor(cancelled models.ProjRequests) {
+	var project models.Projects
+	if err := config.DB.Where("project_id = ?", cancelled.PID).First(&project).Error; err != nil {
+		log.Printf("Failed to fetch project for cancellation email: %v", err)
+		return
+	}
+
+	var professor models.User
+	if err := config.DB.Where("uid = ?", project.CreatorID).First(&professor).Error; err != nil {
+		log.Printf("Failed to fetch professor for cancellation email: %v", err)
+		return
+	}
+
+	var student models.User
+	if err := config.DB.Where("uid = ?", cancelled.UID).First(&student).Error; err != nil {
+		log.Printf("Failed to fetch student for cancellation email: %v", err)
+		return
+	}
+
+	loc := time.UTC
+	if window, ok := findSlotWindow(config.DB, project.CreatorID, *cancelled.InterviewStartsAt); ok {
+		if windowLoc, err := loadLocation(window.Timezone); err == nil {
+			loc = windowLoc
+		}
+	}
+	slotDate, slotTime := formatInterviewSlot(*cancelled.InterviewStartsAt, *cancelled.InterviewEndsAt, loc)
+
+	// Withdraw the calendar invite sent when the slot was booked
+	invite := interviewCancellationInvite(cancelled, project.Name, professor.Email, student.Email)
+
+	emailMessage, err := buildInterviewBookingEmail(professor.Email, loadEmailLocale(professor.Uid), professor.Name, student.Name, project.Name, "cancelled", slotDate+", "+slotTime)
+	if err != nil {
+		log.Printf("Failed to build cancellation email to professor %s: %v", professor.Email, err)
+		return
+	}
+	emailMessage.Attachments = []utils.EmailAttachment{invite}
+	dispatchEmail(models.NotificationInterviewScheduled, professor.Uid, emailMessage,
+		fmt.Sprintf("%s cancelled their interview for %s: %s, %s.", student.Name, project.Name, slotDate, slotTime))
+}
+
+// CancelInterviewBooking releases the interview slot booked by an applicant so they can pick another
+func CancelInterviewBooking(c echo.Context) error {
+	applicationID := c.Param("appId")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can cancel their interview booking"})
+	}
+
+	var application models.ProjRequests
+	if err := config.DB.Where("id = ? AND uid = ?", applicationID, userData.GetUID()).First(&application).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Application not found"})
+	}
+
+	var project models.Projects
+	if err := config.DB.Where("project_id = ?", application.PID).First(&project).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	}
+
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Take the same professor lock as bookings, then re-read the application so a concurrent reschedule is not lost
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uid = ?", project.CreatorID).First(&models.User{}).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Professor not found"})
+	}
+	if err := tx.Where("id = ?", application.ID).First(&application).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Application not found"})
+	}
+
+	if application.Status != "interview" || application.InterviewStartsAt == nil {
+		tx.Rollback()
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "No interview slot is booked for this application"})
+	}
+	if !application.InterviewStartsAt.After(time.Now()) {
+		tx.Rollback()
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Interviews that have already started cannot be cancelled"})
+	}
+
+	cancelledStart, cancelledEnd := *application.InterviewStartsAt, *application.InterviewEndsAt
+	updates := map[string]interface{}{
+		"interview_starts_at":    nil,
+		"interview_ends_at":      nil,
+		"interview_cancelled_at": time.Now(),
+		"interview_date":         "",
+		"interview_time":         "",
+	}
+
+	if err := tx.Model(&application).Updates(updates).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to cancel interview"})
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
+	}
+
+	// Let the professor know the slot is free again
+	cancelled := application
+	cancelled.InterviewStartsAt, cancelled.InterviewEndsAt = &cancelledStart, &cancelledEnd
+	go sendInterviewCancellationToProfessor(cancelled)
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "Interview booking cancelled successfully"})
+}
@@ -0,0 +1,463 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm/clause"
+)
+
+// notificationEventTypes lists the event types users can configure
+var notificationEventTypes = []string{
+	models.NotificationApplicationSubmitted,
+	models.NotificationStatusChanged,
+	models.NotificationInterviewScheduled,
+	models.NotificationFeedbackReceived,
+	models.NotificationWorkingUserRemoved,
+	models.NotificationDirectMessage,
+	models.NotificationQuestionAsked,
+	models.NotificationQuestionAnswered,
+	models.NotificationAnnouncement,
+	models.NotificationTaskAssigned,
+	models.NotificationTaskComment,
+	models.NotificationHoursReviewed,
+	models.NotificationProjectCompleted,
+}
+
+// Digest schedule used until a user picks their own
+const (
+	defaultDigestTimezone = "UTC"
+	defaultDigestHour     = 8
+	defaultWeeklyDigest   = int(time.Monday)
+	digestTick            = 15 * time.Minute
+)
+
+// isNotificationEventType checks an event type against notificationEventTypes
+func isNotificationEventType(eventType string) bool {
+	for _, known := range notificationEventTypes {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultNotificationPreference is how an event type reaches a user who has not changed it
+func defaultNotificationPreference(uid, eventType string) models.NotificationPreference {
+	return models.NotificationPreference{
+		UserUID:      uid,
+		EventType:    eventType,
+		EmailEnabled: true,
+		InAppEnabled: true,
+		Delivery:     models.DeliveryImmediate,
+	}
+}
+
+// loadNotificationPreferences resolves one event type's preference for many users, filling in defaults
+func loadNotificationPreferences(uids []string, eventType string) map[string]models.NotificationPreference {
+	preferences := make(map[string]models.NotificationPreference, len(uids))
+	for _, uid := range uids {
+		preferences[uid] = defaultNotificationPreference(uid, eventType)
+	}
+	if len(uids) == 0 {
+		return preferences
+	}
+
+	var stored []models.NotificationPreference
+	config.DB.Where("user_uid IN ? AND event_type = ?", uids, eventType).Find(&stored)
+	for _, preference := range stored {
+		preferences[preference.UserUID] = preference
+	}
+	return preferences
+}
+
+// loadNotificationSettings returns a user's digest schedule, or the defaults
+func loadNotificationSettings(uid string) models.NotificationSettings {
+	settings := models.NotificationSettings{
+		UserUID:         uid,
+		Timezone:        defaultDigestTimezone,
+		DigestHour:      defaultDigestHour,
+		WeeklyDigestDay: defaultWeeklyDigest,
+	}
+	config.DB.Where("user_uid = ?", uid).First(&settings)
+	return settings
+}
+
+// loadEmailLocales returns the email locale of each user, keyed by UID; users without one get the default
+func loadEmailLocales(uids []string) map[string]string {
+	locales := make(map[string]string, len(uids))
+	for _, uid := range uids {
+		locales[uid] = utils.DefaultEmailLocale
+	}
+	if len(uids) == 0 {
+		return locales
+	}
+
+	var stored []models.NotificationSettings
+	config.DB.Select("user_uid", "locale").Where("user_uid IN ? AND locale <> ''", uids).Find(&stored)
+	for _, settings := range stored {
+		locales[settings.UserUID] = utils.NormalizeEmailLocale(settings.Locale)
+	}
+	return locales
+}
+
+// loadEmailLocale returns the email locale of one user
+func loadEmailLocale(uid string) string {
+	return loadEmailLocales([]string{uid})[uid]
+}
+
+// dispatchEmails sends, queues or drops emails about one event type according to each recipient's preference;
+// messages with attachments are never held back for a digest.
+// recipients[i] is the UID behind messages[i]; summaries[i] is the line shown for it in a digest.
+// It blocks on SMTP, so call it from a goroutine.
+func dispatchEmails(eventType string, recipients []string, messages []*utils.EmailMessage, summaries []string) {
+	preferences := loadNotificationPreferences(recipients, eventType)
+
+	var immediate []*utils.EmailMessage
+	var queued []models.NotificationDigestEntry
+	for i, uid := range recipients {
+		preference := preferences[uid]
+		switch {
+		case !preference.EmailEnabled:
+			continue
+		case preference.Delivery == models.DeliveryImmediate, len(messages[i].Attachments) > 0:
+			// Digests only carry a summary line, so messages with attachments such as calendar invites go out now
+			immediate = append(immediate, messages[i])
+		default:
+			queued = append(queued, models.NotificationDigestEntry{
+				RecipientUID: uid,
+				EventType:    eventType,
+				Delivery:     preference.Delivery,
+				Subject:      messages[i].Subject,
+				Summary:      summaries[i],
+			})
+		}
+	}
+
+	if len(queued) > 0 {
+		if err := config.DB.Create(&queued).Error; err != nil {
+			log.Printf("Failed to queue %d %s emails for digest: %v", len(queued), eventType, err)
+		}
+	}
+
+	switch len(immediate) {
+	case 0:
+	case 1:
+		if err := utils.SendEmail(utils.LoadEmailConfig(), immediate[0]); err != nil {
+			log.Printf("Failed to send %s email to %s: %v", eventType, immediate[0].To[0], err)
+		}
+	default:
+		errs := utils.SendBatchEmails(utils.LoadEmailConfig(), immediate)
+		for i, err := range errs {
+			if err != nil {
+				log.Printf("Failed to send %s email to %s: %v", eventType, immediate[i].To[0], err)
+			}
+		}
+	}
+}
+
+// dispatchEmail is dispatchEmails for a single recipient
+func dispatchEmail(eventType, recipientUID string, message *utils.EmailMessage, summary string) {
+	dispatchEmails(eventType, []string{recipientUID}, []*utils.EmailMessage{message}, []string{summary})
+}
+
+// filterInAppNotifications drops notifications whose recipients turned the in-app channel off
+func filterInAppNotifications(notifications []models.Notification) []models.Notification {
+	uidsByType := make(map[string][]string)
+	for _, notification := range notifications {
+		uidsByType[notification.Type] = append(uidsByType[notification.Type], notification.RecipientUID)
+	}
+
+	preferencesByType := make(map[string]map[string]models.NotificationPreference, len(uidsByType))
+	for eventType, uids := range uidsByType {
+		preferencesByType[eventType] = loadNotificationPreferences(uids, eventType)
+	}
+
+	kept := make([]models.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		if preferencesByType[notification.Type][notification.RecipientUID].InAppEnabled {
+			kept = append(kept, notification)
+		}
+	}
+	return kept
+}
+
+// isDigestDue reports whether a digest last sent at last should go out now, given the user's local schedule
+func isDigestDue(last *time.Time, localNow time.Time, digestHour int) bool {
+	if localNow.Hour() < digestHour {
+		return false
+	}
+	if last == nil {
+		return true
+	}
+	lastLocal := last.In(localNow.Location())
+	y1, m1, d1 := lastLocal.Date()
+	y2, m2, d2 := localNow.Date()
+	return y1 != y2 || m1 != m2 || d1 != d2
+}
+
+// sendDigest emails a user their queued entries of one delivery mode and removes them from the queue
+func sendDigest(user models.User, settings models.NotificationSettings, loc *time.Location, delivery string) (bool, error) {
+	var entries []models.NotificationDigestEntry
+	if err := config.DB.Where("recipient_uid = ? AND delivery = ?", user.Uid, delivery).Order("created_at ASC").Find(&entries).Error; err != nil {
+		return false, err
+	}
+	if len(entries) == 0 {
+		return false, nil
+	}
+
+	message, err := buildNotificationDigestEmail(user.Email, settings.Locale, user.Name, delivery, loc, entries)
+	if err != nil {
+		return false, err
+	}
+	if err := utils.SendEmail(utils.LoadEmailConfig(), message); err != nil {
+		return false, err
+	}
+
+	ids := make([]uint, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	return true, config.DB.Where("id IN ?", ids).Delete(&models.NotificationDigestEntry{}).Error
+}
+
+// sendDueDigests sends the daily and weekly digests whose local send time has come
+func sendDueDigests() {
+	now := time.Now()
+
+	var uids []string
+	if err := config.DB.Model(&models.NotificationDigestEntry{}).Distinct().Pluck("recipient_uid", &uids).Error; err != nil {
+		log.Printf("Failed to load digest recipients: %v", err)
+		return
+	}
+
+	for _, uid := range uids {
+		var user models.User
+		if err := config.DB.Where("uid = ?", uid).First(&user).Error; err != nil {
+			// The account is gone; drop its queue
+			config.DB.Where("recipient_uid = ?", uid).Delete(&models.NotificationDigestEntry{})
+			continue
+		}
+
+		settings := loadNotificationSettings(uid)
+		loc, err := loadLocation(settings.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		localNow := now.In(loc)
+
+		updates := map[string]interface{}{}
+		if isDigestDue(settings.LastDailyDigestAt, localNow, settings.DigestHour) {
+			if sent, err := sendDigest(user, settings, loc, models.DeliveryDaily); err != nil {
+				log.Printf("Failed to send daily digest to %s: %v", user.Email, err)
+			} else if sent {
+				updates["last_daily_digest_at"] = now
+			}
+		}
+		if int(localNow.Weekday()) == settings.WeeklyDigestDay && isDigestDue(settings.LastWeeklyDigestAt, localNow, settings.DigestHour) {
+			if sent, err := sendDigest(user, settings, loc, models.DeliveryWeekly); err != nil {
+				log.Printf("Failed to send weekly digest to %s: %v", user.Email, err)
+			} else if sent {
+				updates["last_weekly_digest_at"] = now
+			}
+		}
+
+		if len(updates) > 0 {
+			settings.UserUID = uid
+			if err := config.DB.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_uid"}},
+				DoUpdates: clause.Assignments(updates),
+			}).Create(&settings).Error; err != nil {
+				log.Printf("Failed to record digest for %s: %v", uid, err)
+			}
+		}
+	}
+}
+
+// StartNotificationDigests starts a goroutine that sends daily and weekly email digests in each user's timezone
+func StartNotificationDigests() {
+	ticker := time.NewTicker(digestTick)
+	go func() {
+		for range ticker.C {
+			sendDueDigests()
+		}
+	}()
+}
+
+// notificationPreferenceView is one event type's preference as exposed by the API
+type notificationPreferenceView struct {
+	EventType string   `json:"eventType"`
+	Channels  []string `json:"channels"` // Any of "email", "in_app"; empty means none
+	Delivery  string   `json:"delivery"` // Email delivery: immediate, daily or weekly
+}
+
+// GetNotificationPreferences returns the user's preference for every event type, their digest schedule and email language
+func GetNotificationPreferences(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+	uid := userData.GetUID()
+
+	var stored []models.NotificationPreference
+	if err := config.DB.Where("user_uid = ?", uid).Find(&stored).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch notification preferences"})
+	}
+	byType := make(map[string]models.NotificationPreference, len(stored))
+	for _, preference := range stored {
+		byType[preference.EventType] = preference
+	}
+
+	preferences := make([]notificationPreferenceView, 0, len(notificationEventTypes))
+	for _, eventType := range notificationEventTypes {
+		preference, ok := byType[eventType]
+		if !ok {
+			preference = defaultNotificationPreference(uid, eventType)
+		}
+		channels := []string{}
+		if preference.EmailEnabled {
+			channels = append(channels, "email")
+		}
+		if preference.InAppEnabled {
+			channels = append(channels, "in_app")
+		}
+		preferences = append(preferences, notificationPreferenceView{
+			EventType: eventType,
+			Channels:  channels,
+			Delivery:  preference.Delivery,
+		})
+	}
+
+	settings := loadNotificationSettings(uid)
+	return c.JSON(http.StatusOK, echo.Map{
+		"preferences":     preferences,
+		"timezone":        settings.Timezone,
+		"locale":          utils.NormalizeEmailLocale(settings.Locale),
+		"locales":         utils.EmailLocales(),
+		"digestHour":      settings.DigestHour,
+		"weeklyDigestDay": settings.WeeklyDigestDay,
+	})
+}
+
+// UpdateNotificationPreferences changes the user's per-event preferences, digest schedule and email language
+func UpdateNotificationPreferences(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+	uid := userData.GetUID()
+
+	// Parse request body; every field is optional
+	var requestBody struct {
+		Preferences     []notificationPreferenceView `json:"preferences"`
+		Timezone        *string                      `json:"timezone"`
+		Locale          *string                      `json:"locale"`
+		DigestHour      *int                         `json:"digestHour"`
+		WeeklyDigestDay *int                         `json:"weeklyDigestDay"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	// Validate everything before writing anything
+	rows := make([]models.NotificationPreference, 0, len(requestBody.Preferences))
+	for _, view := range requestBody.Preferences {
+		if !isNotificationEventType(view.EventType) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Unknown event type: " + view.EventType})
+		}
+		if view.Delivery == "" {
+			view.Delivery = models.DeliveryImmediate
+		}
+		if view.Delivery != models.DeliveryImmediate && view.Delivery != models.DeliveryDaily && view.Delivery != models.DeliveryWeekly {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Delivery must be immediate, daily or weekly"})
+		}
+
+		row := models.NotificationPreference{UserUID: uid, EventType: view.EventType, Delivery: view.Delivery}
+		for _, channel := range view.Channels {
+			switch channel {
+			case "email":
+				row.EmailEnabled = true
+			case "in_app":
+				row.InAppEnabled = true
+			case "none":
+			default:
+				return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Unknown channel: %s", channel)})
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	settings := loadNotificationSettings(uid)
+	if requestBody.Timezone != nil {
+		if _, err := loadLocation(*requestBody.Timezone); err != nil || *requestBody.Timezone == "" {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Unknown timezone"})
+		}
+		settings.Timezone = *requestBody.Timezone
+	}
+	if requestBody.Locale != nil {
+		if !utils.IsSupportedEmailLocale(*requestBody.Locale) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Unsupported locale"})
+		}
+		settings.Locale = *requestBody.Locale
+	}
+	if requestBody.DigestHour != nil {
+		if *requestBody.DigestHour < 0 || *requestBody.DigestHour > 23 {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "digestHour must be between 0 and 23"})
+		}
+		settings.DigestHour = *requestBody.DigestHour
+	}
+	if requestBody.WeeklyDigestDay != nil {
+		if *requestBody.WeeklyDigestDay < 0 || *requestBody.WeeklyDigestDay > 6 {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "weeklyDigestDay must be between 0 (Sunday) and 6 (Saturday)"})
+		}
+		settings.WeeklyDigestDay = *requestBody.WeeklyDigestDay
+	}
+
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if len(rows) > 0 {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_uid"}, {Name: "event_type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"email_enabled", "in_app_enabled", "delivery", "updated_at"}),
+		}).Create(&rows).Error; err != nil {
+			tx.Rollback()
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save notification preferences"})
+		}
+	}
+
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_uid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"timezone", "locale", "digest_hour", "weekly_digest_day", "updated_at"}),
+	}).Create(&settings).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save digest schedule"})
+	}
+
+	// Already-queued entries follow the new preference: dropped when email is off, flushed with the
+	// next daily digest when switching to immediate, otherwise moved to the chosen digest
+	for _, row := range rows {
+		queued := tx.Model(&models.NotificationDigestEntry{}).Where("recipient_uid = ? AND event_type = ?", uid, row.EventType)
+		switch {
+		case !row.EmailEnabled:
+			queued.Delete(&models.NotificationDigestEntry{})
+		case row.Delivery == models.DeliveryImmediate:
+			queued.Update("delivery", models.DeliveryDaily)
+		default:
+			queued.Update("delivery", row.Delivery)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
+	}
+
+	return GetNotificationPreferences(c)
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	readNotificationRetention   = 90 * 24 * time.Hour  // Read notifications are purged after this
+	unreadNotificationRetention = 180 * 24 * time.Hour // Unread ones are kept longer
+)
+
+// createNotifications stores in-app notifications for recipients who have the in-app channel on;
+// failures are logged so they never break the triggering request
+func createNotifications(notifications []models.Notification) {
+	notifications = filterInAppNotifications(notifications)
+	if len(notifications) == 0 {
+		return
+	}
+	if err := config.DB.Create(&notifications).Error; err != nil {
+		log.Printf("Failed to create %d notifications: %v", len(notifications), err)
+		return
+	}
+
+	// Push each notification to the recipient's open streams
+	for _, notification := range notifications {
+		publishEvent(notification.RecipientUID, notification.Type, notification)
+	}
+}
+
+// applicationNotification builds a notification about an application
+func applicationNotification(recipientUID, notificationType, title, body string, application models.ProjRequests) models.Notification {
+	applicationID := application.ID
+	return models.Notification{
+		RecipientUID:  recipientUID,
+		Type:          notificationType,
+		Title:         title,
+		Body:          body,
+		PID:           application.PID,
+		ApplicationID: &applicationID,
+	}
+}
+
+// statusChangeNotification tells a student their application moved to a new status
+func statusChangeNotification(application models.ProjRequests, projectName, status string) models.Notification {
+	return applicationNotification(application.UID, models.NotificationStatusChanged,
+		fmt.Sprintf("Application update for %s", projectName),
+		fmt.Sprintf("Your application for %s is now %s.", projectName, formatApplicationStatus(status)),
+		application)
+}
+
+// interviewNotification tells a student about an interview, or invites them to book one
+func interviewNotification(application models.ProjRequests, projectName, date, clock string, selfBook bool) models.Notification {
+	body := fmt.Sprintf("You have been invited to interview for %s.", projectName)
+	if selfBook {
+		body = fmt.Sprintf("You have been invited to interview for %s. Pick a slot that suits you.", projectName)
+	} else if date != "" {
+		body = fmt.Sprintf("Your interview for %s is scheduled for %s %s.", projectName, date, clock)
+	}
+	return applicationNotification(application.UID, models.NotificationInterviewScheduled,
+		fmt.Sprintf("Interview for %s", projectName), body, application)
+}
+
+// feedbackNotification tells a student that a professor left feedback on their application
+func feedbackNotification(application models.ProjRequests, projectName string) models.Notification {
+	return applicationNotification(application.UID, models.NotificationFeedbackReceived,
+		fmt.Sprintf("New feedback on %s", projectName),
+		fmt.Sprintf("The professor left feedback on your application for %s.", projectName),
+		application)
+}
+
+// formatApplicationStatus turns a status value into readable text
+func formatApplicationStatus(status string) string {
+	switch status {
+	case "under_review":
+		return "under review"
+	default:
+		return status
+	}
+}
+
+// purgeOldNotifications deletes notifications past their retention period
+func purgeOldNotifications() {
+	now := time.Now()
+	result := config.DB.Unscoped().
+		Where("(read_at IS NOT NULL AND created_at < ?) OR created_at < ?", now.Add(-readNotificationRetention), now.Add(-unreadNotificationRetention)).
+		Delete(&models.Notification{})
+	if result.Error != nil {
+		log.Printf("Failed to purge old notifications: %v", result.Error)
+	}
+}
+
+// StartNotificationRetention starts a goroutine that periodically purges old notifications
+func StartNotificationRetention() {
+	ticker := time.NewTicker(24 * time.Hour)
+	go func() {
+		purgeOldNotifications()
+		for range ticker.C {
+			purgeOldNotifications()
+		}
+	}()
+}
+
+// GetMyNotifications returns a page of the user's notifications, newest first
+func GetMyNotifications(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Get pagination parameters
+	page := 1
+	pageSize := 20
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if pageSizeParam := c.QueryParam("pageSize"); pageSizeParam != "" {
+		if ps, err := strconv.Atoi(pageSizeParam); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+
+	query := config.DB.Model(&models.Notification{}).Where("recipient_uid = ?", userData.GetUID())
+	if c.QueryParam("unread") == "true" {
+		query = query.Where("read_at IS NULL")
+	}
+	if notificationType := c.QueryParam("type"); notificationType != "" {
+		query = query.Where("type = ?", notificationType)
+	}
+
+	// Get total count before pagination
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to count notifications"})
+	}
+
+	// Apply pagination
+	notifications := []models.Notification{}
+	offset := (page - 1) * pageSize
+	if err := query.Offset(offset).Limit(pageSize).Order("created_at DESC").Find(&notifications).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch notifications"})
+	}
+
+	var unreadCount int64
+	config.DB.Model(&models.Notification{}).Where("recipient_uid = ? AND read_at IS NULL", userData.GetUID()).Count(&unreadCount)
+
+	totalPages := int((totalCount + int64(pageSize) - 1) / int64(pageSize))
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"notifications": notifications,
+		"count":         len(notifications),
+		"unread":        unreadCount,
+		"total":         totalCount,
+		"page":          page,
+		"pageSize":      pageSize,
+		"totalPages":    totalPages,
+	})
+}
+
+// GetUnreadNotificationCount returns how many unread notifications the user has, for the bell badge
+func GetUnreadNotificationCount(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	var unreadCount int64
+	if err := config.DB.Model(&models.Notification{}).
+		Where("recipient_uid = ? AND read_at IS NULL", userData.GetUID()).
+		Count(&unreadCount).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to count notifications"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"unread": unreadCount})
+}
+
+// MarkNotificationRead marks one of the user's notifications as read
+func MarkNotificationRead(c echo.Context) error {
+	notificationID := c.Param("id")
+	if notificationID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Notification ID is required"})
+	}
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	var notification models.Notification
+	if err := config.DB.Where("id = ? AND recipient_uid = ?", notificationID, userData.GetUID()).First(&notification).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Notification not found"})
+	}
+
+	if notification.ReadAt == nil {
+		now := time.Now()
+		if err := config.DB.Model(&notification).Update("read_at", now).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to mark notification as read"})
+		}
+		notification.ReadAt = &now
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":      "Notification marked as read",
+		"notification": notification,
+	})
+}
+
+// MarkAllNotificationsRead marks every unread notification of the user as read
+func MarkAllNotificationsRead(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	result := config.DB.Model(&models.Notification{}).
+		Where("recipient_uid = ? AND read_at IS NULL", userData.GetUID()).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to mark notifications as read"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "All notifications marked as read",
+		"updated": result.RowsAffected,
+	})
+}
@@ -215,6 +215,7 @@ func GeneratePlacementRoadmap(c echo.Context) error {
 			GeneratedBy:    "gemini-dedup-cached",
 		}
 		config.DB.Create(&roadmap)
+		publishRoadmapReady(userID, roadmap)
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
 			"message":   "Placement roadmap generated successfully",
@@ -260,6 +261,7 @@ func GeneratePlacementRoadmap(c echo.Context) error {
 		GeneratedBy:    "gemini",
 	}
 	config.DB.Create(&roadmap)
+	publishRoadmapReady(userID, roadmap)
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"message":   "Placement roadmap generated successfully",
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Verified participation states
+const (
+	participationActive    = "active"    // Currently a working user
+	participationCompleted = "completed" // Marked completed by faculty
+	participationFormer    = "former"    // Accepted, but no longer working on the project
+)
+
+// verifiedProjectEntry is one project a student demonstrably worked on, derived from platform records
+// rather than self-declared profile data
+type verifiedProjectEntry struct {
+	ProjectID       uint       `json:"projectId"` // Numeric ID, as used by platformProjects
+	PID             string     `json:"pid"`
+	Name            string     `json:"name"`
+	SupervisorName  string     `json:"supervisorName"`
+	Role            string     `json:"role"`
+	Status          string     `json:"status"`
+	StartedAt       *time.Time `json:"startedAt"` // When the application was accepted, if there was one
+	EndedAt         *time.Time `json:"endedAt"`   // When the work was marked completed
+	Hours           float64    `json:"hours"`     // Approved logged hours
+	CertificateCode string     `json:"certificateCode,omitempty"`
+	Sources         []string   `json:"sources"` // Records backing the entry: application, membership, completion
+}
+
+// loadVerifiedProjectHistory derives a student's participation history from accepted applications,
+// WorkingUsers membership and completions. Active projects come first, then the most recent.
+func loadVerifiedProjectHistory(uid string) []verifiedProjectEntry {
+	entries := make(map[string]*verifiedProjectEntry)
+	entryFor := func(projectID string) *verifiedProjectEntry {
+		entry, ok := entries[projectID]
+		if !ok {
+			entry = &verifiedProjectEntry{PID: projectID, Status: participationFormer}
+			entries[projectID] = entry
+		}
+		return entry
+	}
+
+	var applications []models.ProjRequests
+	config.DB.Where("uid = ? AND status = ?", uid, "accepted").Find(&applications)
+	for _, app := range applications {
+		startedAt := app.TimeCreated
+		if app.DecidedAt != nil {
+			startedAt = *app.DecidedAt
+		}
+		entry := entryFor(app.PID)
+		entry.StartedAt = &startedAt
+		entry.Sources = append(entry.Sources, "application")
+	}
+
+	var memberProjects []models.Projects
+	config.DB.Where("? = ANY(working_users)", uid).Find(&memberProjects)
+	for _, project := range memberProjects {
+		entry := entryFor(project.ProjectID)
+		entry.Status = participationActive
+		entry.Sources = append(entry.Sources, "membership")
+	}
+
+	var completions []models.ProjectCompletion
+	config.DB.Where("uid = ? AND revoked_at IS NULL", uid).Find(&completions)
+	for _, completion := range completions {
+		completedAt := completion.CompletedAt
+		entry := entryFor(completion.PID)
+		entry.Status = participationCompleted
+		entry.Role = completion.Role
+		entry.EndedAt = &completedAt
+		entry.Hours = completion.Hours
+		entry.CertificateCode = completion.CertificateCode
+		entry.Sources = append(entry.Sources, "completion")
+	}
+
+	if len(entries) == 0 {
+		return []verifiedProjectEntry{}
+	}
+
+	projectIDs := make([]string, 0, len(entries))
+	for projectID := range entries {
+		projectIDs = append(projectIDs, projectID)
+	}
+
+	// Deleted projects stay part of the history
+	var projects []models.Projects
+	config.DB.Unscoped().Where("project_id IN ?", projectIDs).Find(&projects)
+	creatorIDs := make([]string, 0, len(projects))
+	for _, project := range projects {
+		creatorIDs = append(creatorIDs, project.CreatorID)
+	}
+	supervisors := loadParticipants(creatorIDs)
+
+	var hours []struct {
+		PID   string
+		Hours float64
+	}
+	config.DB.Model(&models.TimeEntry{}).
+		Select("p_id, SUM(hours) AS hours").
+		Where("uid = ? AND status = ? AND p_id IN ?", uid, models.TimeEntryApproved, projectIDs).
+		Group("p_id").
+		Scan(&hours)
+
+	history := make([]verifiedProjectEntry, 0, len(projects))
+	for _, project := range projects {
+		entry := entries[project.ProjectID]
+		entry.ProjectID = project.ID
+		entry.Name = project.Name
+		if supervisor, ok := supervisors[project.CreatorID]; ok {
+			entry.SupervisorName, _ = supervisor["name"].(string)
+		}
+		if entry.Role == "" {
+			entry.Role = strings.Join(project.PositionType, ", ")
+		}
+		// Completions snapshot their hours; otherwise use what has been approved so far
+		if entry.Status != participationCompleted {
+			for _, total := range hours {
+				if total.PID == project.ProjectID {
+					entry.Hours = total.Hours
+				}
+			}
+		}
+		history = append(history, *entry)
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		if (history[i].Status == participationActive) != (history[j].Status == participationActive) {
+			return history[i].Status == participationActive
+		}
+		return participationDate(history[i]).After(participationDate(history[j]))
+	})
+	return history
+}
+
+// participationDate is the most recent date known for an entry, used to order the history
+func participationDate(entry verifiedProjectEntry) time.Time {
+	if entry.EndedAt != nil {
+		return *entry.EndedAt
+	}
+	if entry.StartedAt != nil {
+		return *entry.StartedAt
+	}
+	return time.Time{}
+}
+
+// unverifiedPlatformProjects returns the requested platform project IDs that are neither in the student's
+// verified history nor already on their profile
+func unverifiedPlatformProjects(uid string, requested, existing []int64) []int64 {
+	var added []int64
+	for _, projectID := range requested {
+		if !slices.Contains(existing, projectID) {
+			added = append(added, projectID)
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	var verified []int64
+	for _, entry := range loadVerifiedProjectHistory(uid) {
+		verified = append(verified, int64(entry.ProjectID))
+	}
+
+	var unverified []int64
+	for _, projectID := range added {
+		if !slices.Contains(verified, projectID) {
+			unverified = append(unverified, projectID)
+		}
+	}
+	return unverified
+}
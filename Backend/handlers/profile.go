@@ -71,6 +71,14 @@ func UpdateStudentProfile(c echo.Context) error {
 	var student models.Students
 	result := config.DB.Where("uid = ?", userData.UID).First(&student)
 
+	// Platform projects must come from the student's verified history; ones already on the profile are kept
+	if unverified := unverifiedPlatformProjects(userData.UID, updateRequest.PlatformProjects, student.PlatformProjects); len(unverified) > 0 {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"error":      "Platform projects must be projects you have worked on",
+			"unverified": unverified,
+		})
+	}
+
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			// Create new student record if doesn't exist
@@ -189,7 +197,8 @@ func GetStudentProfile(c echo.Context) error {
 	}
 
 	return c.JSON(http.StatusOK, echo.Map{
-		"student": student,
+		"student":          student,
+		"verifiedProjects": loadVerifiedProjectHistory(userData.UID),
 	})
 }
 
@@ -356,8 +365,12 @@ func GetUserProfileByUID(c echo.Context) error {
 				"resumeLink":       student.Resume,
 				"publicationsLink": student.Publications,
 				"researchInterest": student.ResearchInterest,
+				"projectsDetails":  student.ProjectsDetails, // Self-declared
 			}
 		}
+
+		// Derived from platform records, so kept apart from the self-declared projects
+		userInfo["verifiedProjects"] = loadVerifiedProjectHistory(targetUID)
 	}
 
 	// If user is faculty, we could add professor-specific info here
@@ -455,7 +468,7 @@ func ExploreUsers(c echo.Context) error {
 	})
 }
 
-// AddPlatformProject adds a project ID to the student's platform projects
+// AddPlatformProject adds a project the student has verifiably worked on to their platform projects
 func AddPlatformProject(c echo.Context) error {
 	// Get authenticated user from context
 	userDataInterface := c.Get("userData")
@@ -513,6 +526,13 @@ func AddPlatformProject(c echo.Context) error {
 		})
 	}
 
+	// Only projects the student has verifiably worked on can be added
+	if len(unverifiedPlatformProjects(userData.UID, []int64{updateRequest.ProjectID}, nil)) > 0 {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"error": "You can only add projects you have worked on",
+		})
+	}
+
 	// Check if project is already in the list
 	for _, projectID := range student.PlatformProjects {
 		if projectID == updateRequest.ProjectID {
@@ -5,9 +5,12 @@ import (
 	"backend/interfaces"
 	"backend/models"
 	"backend/utils"
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/lib/pq"
@@ -54,6 +57,21 @@ func CreateProject(c echo.Context) error {
 		return c.JSON(http.StatusConflict, echo.Map{"error": "Project already exists"})
 	}
 
+	reviewers, ok := normalizeReviewers(tx, userData.GetUID(), newProject.Reviewers)
+	if !ok {
+		tx.Rollback()
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Reviewers must be faculty accounts"})
+	}
+
+	if section, ok := validateProfileSections(newProject.RequiredProfileSections); !ok {
+		tx.Rollback()
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Unknown profile section: " + section})
+	}
+	if newProject.MinYear < 0 {
+		tx.Rollback()
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Minimum year cannot be negative"})
+	}
+
 	// ...
 	project := models.Projects{
 		ProjectID:      pid,
@@ -69,6 +87,14 @@ func CreateProject(c echo.Context) error {
 		Duration:       newProject.Duration,
 		PositionType:   pq.StringArray(newProject.PositionType),
 		Deadline:       newProject.Deadline,
+		BlindReview:    newProject.BlindReview,
+		Reviewers:      reviewers,
+
+		// Eligibility rules
+		MinYear:                 newProject.MinYear,
+		AllowedDegrees:          pq.StringArray(newProject.AllowedDegrees),
+		RequiredSkills:          pq.StringArray(newProject.RequiredSkills),
+		RequiredProfileSections: pq.StringArray(newProject.RequiredProfileSections),
 	}
 	if err := tx.Create(&project).Error; err != nil {
 		tx.Rollback()
@@ -83,6 +109,8 @@ func CreateProject(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save Project"})
 	}
 
+	emitWebhookEvent(models.WebhookProjectCreated, project, nil, echo.Map{"project": project})
+
 	return c.JSON(http.StatusCreated, project)
 }
 
@@ -234,6 +262,7 @@ func EditProject(c echo.Context) error {
 	}
 
 	updates := make(map[string]interface{})
+	previousWorkingUsers := append([]string{}, existingProject.WorkingUsers...)
 
 	if updateData.Name != nil {
 		// Check for duplicate names with row-level locking to prevent race conditions
@@ -288,6 +317,40 @@ func EditProject(c echo.Context) error {
 		updates["deadline"] = *updateData.Deadline
 	}
 
+	if updateData.BlindReview != nil {
+		updates["blind_review"] = *updateData.BlindReview
+	}
+	if updateData.Reviewers != nil {
+		reviewers, ok := normalizeReviewers(tx, userData.GetUID(), *updateData.Reviewers)
+		if !ok {
+			tx.Rollback()
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Reviewers must be faculty accounts"})
+		}
+		updates["reviewers"] = reviewers
+	}
+
+	// Eligibility rules
+	if updateData.MinYear != nil {
+		if *updateData.MinYear < 0 {
+			tx.Rollback()
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Minimum year cannot be negative"})
+		}
+		updates["min_year"] = *updateData.MinYear
+	}
+	if updateData.AllowedDegrees != nil {
+		updates["allowed_degrees"] = pq.StringArray(*updateData.AllowedDegrees)
+	}
+	if updateData.RequiredSkills != nil {
+		updates["required_skills"] = pq.StringArray(*updateData.RequiredSkills)
+	}
+	if updateData.RequiredProfileSections != nil {
+		if section, ok := validateProfileSections(*updateData.RequiredProfileSections); !ok {
+			tx.Rollback()
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Unknown profile section: " + section})
+		}
+		updates["required_profile_sections"] = pq.StringArray(*updateData.RequiredProfileSections)
+	}
+
 	if err := tx.Model(&existingProject).Updates(updates).Error; err != nil {
 		tx.Rollback()
 		// Check if it's a duplicate key error (race condition caught)
@@ -297,6 +360,18 @@ func EditProject(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update project"})
 	}
 
+	// Closing the project expires any drafts students were still working on
+	closed := updateData.IsActive != nil && !*updateData.IsActive
+	if updateData.Deadline != nil && isDeadlinePassed(*updateData.Deadline, time.Now()) {
+		closed = true
+	}
+	if closed {
+		if err := expireProjectDrafts(tx, projectID); err != nil {
+			tx.Rollback()
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to expire application drafts"})
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
 	}
@@ -306,6 +381,13 @@ func EditProject(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch updated project"})
 	}
 
+	// Replacing the working users list can remove members too
+	for _, uid := range previousWorkingUsers {
+		if !slices.Contains(updatedProject.WorkingUsers, uid) {
+			emitWorkingUserRemoved(updatedProject, uid)
+		}
+	}
+
 	return c.JSON(http.StatusOK, echo.Map{
 		"message": "Project updated successfully",
 		"project": updatedProject,
@@ -320,7 +402,8 @@ func GetProject(c echo.Context) error {
 
 	type ProjectWithUser struct {
 		models.Projects
-		User models.User `json:"user"`
+		User models.User     `json:"user"`
+		QA   projectQACounts `json:"qa"` // Visible questions on the Q&A board and how many are answered
 	}
 
 	var project models.Projects
@@ -337,6 +420,7 @@ func GetProject(c echo.Context) error {
 	projectWithUser := ProjectWithUser{
 		Projects: project,
 		User:     user,
+		QA:       loadProjectQACounts(project.ProjectID),
 	}
 
 	return c.JSON(http.StatusOK, projectWithUser)
@@ -372,6 +456,11 @@ func DeleteProject(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to delete project"})
 	}
 
+	if err := expireProjectDrafts(tx, projectID); err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to expire application drafts"})
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
 	}
@@ -519,7 +608,7 @@ func RemoveWorkingUser(c echo.Context) error {
 
 	// Update the application status to rejected
 	if err := tx.Exec(
-		"UPDATE proj_requests SET status = 'rejected' WHERE p_id = ? AND uid = ?",
+		"UPDATE proj_requests SET status = 'rejected', decided_at = NOW() WHERE p_id = ? AND uid = ?",
 		projectID, userID,
 	).Error; err != nil {
 		tx.Rollback()
@@ -531,6 +620,16 @@ func RemoveWorkingUser(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
 	}
 
+	createNotifications([]models.Notification{{
+		RecipientUID: userID,
+		Type:         models.NotificationWorkingUserRemoved,
+		Title:        fmt.Sprintf("Removed from %s", project.Name),
+		Body:         fmt.Sprintf("You are no longer a member of the project %s.", project.Name),
+		PID:          project.ProjectID,
+	}})
+
+	emitWorkingUserRemoved(project, userID)
+
 	return c.JSON(http.StatusOK, echo.Map{
 		"message": "User removed from project successfully",
 	})
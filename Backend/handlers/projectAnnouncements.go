@@ -0,0 +1,369 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	announcementTitleMaxLength = 200   // Characters per title
+	announcementBodyMaxLength  = 10000 // Characters per body
+)
+
+// projectAnnouncementView is an announcement as shown in a member's feed
+type projectAnnouncementView struct {
+	models.ProjectAnnouncement
+	AuthorName   string `json:"authorName"`
+	Acknowledged bool   `json:"acknowledged"`
+	// Only filled in for the project's owners
+	AckCount    *int64 `json:"ackCount,omitempty"`
+	MemberCount *int   `json:"memberCount,omitempty"`
+}
+
+// isProjectMember reports whether the user owns, reviews or works on the project
+func isProjectMember(project models.Projects, uid string) bool {
+	return canModerateProject(project, uid) || slices.Contains(project.WorkingUsers, uid)
+}
+
+// projectAnnouncementsURL is the page of the frontend that lists a project's announcements
+func projectAnnouncementsURL(projectID string) string {
+	return fmt.Sprintf("%s/project/%s/announcements", os.Getenv("FRONTEND_URL"), projectID)
+}
+
+// findProjectAnnouncement loads the announcement in the :announcementId path parameter of the project
+func findProjectAnnouncement(c echo.Context, projectID string) (models.ProjectAnnouncement, error) {
+	var announcement models.ProjectAnnouncement
+	err := config.DB.Where("id = ? AND p_id = ?", c.Param("announcementId"), projectID).First(&announcement).Error
+	return announcement, err
+}
+
+// sendAnnouncementEmails emails an announcement to the working users, or queues it for their digest
+func sendAnnouncementEmails(project models.Projects, announcement models.ProjectAnnouncement, authorName string, recipientUIDs []string) {
+	if len(recipientUIDs) == 0 {
+		return
+	}
+
+	go func() {
+		var members []models.User
+		if err := config.DB.Where("uid IN ?", recipientUIDs).Find(&members).Error; err != nil {
+			log.Printf("Failed to fetch working users for announcement %d: %v", announcement.ID, err)
+			return
+		}
+		locales := loadEmailLocales(recipientUIDs)
+
+		recipients := make([]string, 0, len(members))
+		messages := make([]*utils.EmailMessage, 0, len(members))
+		summaries := make([]string, 0, len(members))
+		for _, member := range members {
+			message, err := utils.NewTemplateEmail(member.Email, "project_announcement", locales[member.Uid], echo.Map{
+				"Name":             member.Name,
+				"AuthorName":       authorName,
+				"ProjectName":      project.Name,
+				"Title":            announcement.Title,
+				"Body":             announcement.Body,
+				"RequireAck":       announcement.RequireAck,
+				"AnnouncementsURL": projectAnnouncementsURL(project.ProjectID),
+			})
+			if err != nil {
+				log.Printf("Failed to build announcement email to %s: %v", member.Email, err)
+				continue
+			}
+			recipients = append(recipients, member.Uid)
+			messages = append(messages, message)
+			summaries = append(summaries, fmt.Sprintf("%s: %s", project.Name, announcement.Title))
+		}
+
+		dispatchEmails(models.NotificationAnnouncement, recipients, messages, summaries)
+	}()
+}
+
+// PostProjectAnnouncement lets a project's owners post an announcement to all of its working users
+func PostProjectAnnouncement(c echo.Context) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findReviewableProject(projectID, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Title      string `json:"title"`
+		Body       string `json:"body"`
+		RequireAck bool   `json:"requireAck"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	title := strings.TrimSpace(requestBody.Title)
+	body := strings.TrimSpace(requestBody.Body)
+	if title == "" || body == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Title and body are required"})
+	}
+	if len([]rune(title)) > announcementTitleMaxLength {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Title cannot be longer than %d characters", announcementTitleMaxLength)})
+	}
+	if len([]rune(body)) > announcementBodyMaxLength {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Body cannot be longer than %d characters", announcementBodyMaxLength)})
+	}
+
+	announcement := models.ProjectAnnouncement{
+		PID:        projectID,
+		AuthorUID:  userData.GetUID(),
+		Title:      title,
+		Body:       body,
+		RequireAck: requestBody.RequireAck,
+	}
+	if err := config.DB.Create(&announcement).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to post announcement"})
+	}
+
+	var author models.User
+	config.DB.Select("uid, name").Where("uid = ?", userData.GetUID()).First(&author)
+
+	recipients := make([]string, 0, len(project.WorkingUsers))
+	notifications := make([]models.Notification, 0, len(project.WorkingUsers))
+	for _, uid := range project.WorkingUsers {
+		if uid == userData.GetUID() {
+			continue
+		}
+		recipients = append(recipients, uid)
+		notifications = append(notifications, models.Notification{
+			RecipientUID: uid,
+			Type:         models.NotificationAnnouncement,
+			Title:        fmt.Sprintf("%s: %s", project.Name, title),
+			Body:         body,
+			PID:          project.ProjectID,
+		})
+	}
+	createNotifications(notifications)
+	sendAnnouncementEmails(project, announcement, author.Name, recipients)
+
+	ackCount := int64(0)
+	memberCount := len(project.WorkingUsers)
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message":    fmt.Sprintf("Announcement sent to %d working users", len(recipients)),
+		"recipients": len(recipients),
+		"announcement": projectAnnouncementView{
+			ProjectAnnouncement: announcement,
+			AuthorName:          author.Name,
+			AckCount:            &ackCount,
+			MemberCount:         &memberCount,
+		},
+	})
+}
+
+// GetProjectAnnouncements returns a page of a project's announcements, newest first, to its members.
+// Owners also see how many working users acknowledged each announcement that asks for it.
+func GetProjectAnnouncements(c echo.Context) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+	uid := userData.GetUID()
+
+	var project models.Projects
+	if err := config.DB.Where("project_id = ?", projectID).First(&project).Error; err != nil || !isProjectMember(project, uid) {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you are not a member"})
+	}
+	moderator := canModerateProject(project, uid)
+
+	// Get pagination parameters
+	page := 1
+	pageSize := 20
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if pageSizeParam := c.QueryParam("pageSize"); pageSizeParam != "" {
+		if ps, err := strconv.Atoi(pageSizeParam); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+
+	query := config.DB.Model(&models.ProjectAnnouncement{}).Where("p_id = ?", projectID)
+
+	// Get total count before pagination
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to count announcements"})
+	}
+
+	// Apply pagination
+	var announcements []models.ProjectAnnouncement
+	offset := (page - 1) * pageSize
+	if err := query.Offset(offset).Limit(pageSize).Order("created_at DESC, id DESC").Find(&announcements).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch announcements"})
+	}
+
+	announcementIDs := make([]uint, 0, len(announcements))
+	authorUIDs := make([]string, 0, len(announcements))
+	for _, announcement := range announcements {
+		announcementIDs = append(announcementIDs, announcement.ID)
+		authorUIDs = append(authorUIDs, announcement.AuthorUID)
+	}
+
+	authorNames := make(map[string]string)
+	acknowledged := make(map[uint]bool)
+	ackCounts := make(map[uint]int64)
+	if len(announcementIDs) > 0 {
+		var authors []models.User
+		config.DB.Select("uid, name").Where("uid IN ?", authorUIDs).Find(&authors)
+		for _, author := range authors {
+			authorNames[author.Uid] = author.Name
+		}
+
+		var ackedIDs []uint
+		config.DB.Model(&models.AnnouncementAck{}).Where("announcement_id IN ? AND user_uid = ?", announcementIDs, uid).Pluck("announcement_id", &ackedIDs)
+		for _, id := range ackedIDs {
+			acknowledged[id] = true
+		}
+
+		if moderator {
+			// Only acknowledgements from current working users count towards the total
+			var counts []struct {
+				AnnouncementID uint
+				Count          int64
+			}
+			config.DB.Model(&models.AnnouncementAck{}).
+				Select("announcement_id, COUNT(*) AS count").
+				Where("announcement_id IN ? AND user_uid IN ?", announcementIDs, []string(project.WorkingUsers)).
+				Group("announcement_id").
+				Scan(&counts)
+			for _, count := range counts {
+				ackCounts[count.AnnouncementID] = count.Count
+			}
+		}
+	}
+
+	memberCount := len(project.WorkingUsers)
+	views := make([]projectAnnouncementView, 0, len(announcements))
+	for _, announcement := range announcements {
+		view := projectAnnouncementView{
+			ProjectAnnouncement: announcement,
+			AuthorName:          authorNames[announcement.AuthorUID],
+			Acknowledged:        acknowledged[announcement.ID],
+		}
+		if moderator && announcement.RequireAck {
+			ackCount := ackCounts[announcement.ID]
+			view.AckCount = &ackCount
+			view.MemberCount = &memberCount
+		}
+		views = append(views, view)
+	}
+
+	totalPages := int((totalCount + int64(pageSize) - 1) / int64(pageSize))
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"announcements": views,
+		"count":         len(views),
+		"total":         totalCount,
+		"page":          page,
+		"pageSize":      pageSize,
+		"totalPages":    totalPages,
+		"canPost":       moderator,
+	})
+}
+
+// AcknowledgeAnnouncement records that a working user has read an announcement that asks for it
+func AcknowledgeAnnouncement(c echo.Context) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	var project models.Projects
+	if err := config.DB.Where("project_id = ?", projectID).First(&project).Error; err != nil || !slices.Contains(project.WorkingUsers, userData.GetUID()) {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you are not a working user"})
+	}
+
+	announcement, err := findProjectAnnouncement(c, projectID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Announcement not found"})
+	}
+	if !announcement.RequireAck {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "This announcement does not ask for acknowledgement"})
+	}
+
+	ack := models.AnnouncementAck{AnnouncementID: announcement.ID, UserUID: userData.GetUID(), AckedAt: time.Now()}
+	if err := config.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&ack).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to acknowledge announcement"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":        "Announcement acknowledged",
+		"announcementId": announcement.ID,
+	})
+}
+
+// GetAnnouncementAcks lists the project's working users with when each acknowledged an announcement,
+// so owners can follow up with those who have not
+func GetAnnouncementAcks(c echo.Context) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findReviewableProject(projectID, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	announcement, err := findProjectAnnouncement(c, projectID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Announcement not found"})
+	}
+
+	var acks []models.AnnouncementAck
+	config.DB.Where("announcement_id = ?", announcement.ID).Find(&acks)
+	ackedAt := make(map[string]time.Time, len(acks))
+	for _, ack := range acks {
+		ackedAt[ack.UserUID] = ack.AckedAt
+	}
+
+	var members []models.User
+	if len(project.WorkingUsers) > 0 {
+		config.DB.Select("uid, name").Where("uid IN ?", []string(project.WorkingUsers)).Order("name ASC").Find(&members)
+	}
+
+	results := make([]echo.Map, 0, len(members))
+	acknowledged := 0
+	for _, member := range members {
+		var at *time.Time
+		if t, ok := ackedAt[member.Uid]; ok {
+			at = &t
+			acknowledged++
+		}
+		results = append(results, echo.Map{
+			"uid":     member.Uid,
+			"name":    member.Name,
+			"ackedAt": at,
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"announcementId": announcement.ID,
+		"requireAck":     announcement.RequireAck,
+		"members":        results,
+		"acknowledged":   acknowledged,
+		"pending":        len(results) - acknowledged,
+	})
+}
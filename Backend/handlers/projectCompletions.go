@@ -0,0 +1,524 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	evaluationMaxLength    = 5000 // Characters per faculty evaluation
+	projectReviewMaxLength = 3000 // Characters per student review
+	completionRoleMaxLen   = 100  // Characters of the role printed on a certificate
+)
+
+// completionView is a completion with the people and project it refers to
+type completionView struct {
+	models.ProjectCompletion
+	ProjectName    string                `json:"projectName"`
+	SupervisorName string                `json:"supervisorName"`
+	Student        echo.Map              `json:"student,omitempty"`
+	Review         *models.ProjectReview `json:"review"`
+}
+
+// certificateVerifyURL is the public page that confirms a certificate from its code
+func certificateVerifyURL(code string) string {
+	return fmt.Sprintf("%s/certificates/%s", os.Getenv("FRONTEND_URL"), code)
+}
+
+// isValidRating checks a 1-5 star rating
+func isValidRating(rating int) bool {
+	return rating >= 1 && rating <= 5
+}
+
+// loadApprovedHours totals a student's approved logged hours on a project
+func loadApprovedHours(projectID, uid string) float64 {
+	var hours float64
+	config.DB.Model(&models.TimeEntry{}).
+		Select("COALESCE(SUM(hours), 0)").
+		Where("p_id = ? AND uid = ? AND status = ?", projectID, uid, models.TimeEntryApproved).
+		Scan(&hours)
+	return hours
+}
+
+// buildCompletionViews adds project names, supervisors, students and reviews to completions
+func buildCompletionViews(completions []models.ProjectCompletion, includeStudent bool) []completionView {
+	views := make([]completionView, 0, len(completions))
+	if len(completions) == 0 {
+		return views
+	}
+
+	projectIDs := make([]string, 0, len(completions))
+	completionIDs := make([]uint, 0, len(completions))
+	uids := make([]string, 0, len(completions))
+	for _, completion := range completions {
+		projectIDs = append(projectIDs, completion.PID)
+		completionIDs = append(completionIDs, completion.ID)
+		uids = append(uids, completion.UID)
+	}
+
+	// Deleted projects still back the certificates issued for them
+	var projectRows []models.Projects
+	config.DB.Unscoped().Where("project_id IN ?", projectIDs).Find(&projectRows)
+	projects := make(map[string]models.Projects, len(projectRows))
+	for _, project := range projectRows {
+		projects[project.ProjectID] = project
+		uids = append(uids, project.CreatorID)
+	}
+	participants := loadParticipants(uids)
+
+	var reviewRows []models.ProjectReview
+	config.DB.Where("completion_id IN ?", completionIDs).Find(&reviewRows)
+	reviews := make(map[uint]models.ProjectReview, len(reviewRows))
+	for _, review := range reviewRows {
+		reviews[review.CompletionID] = review
+	}
+
+	for _, completion := range completions {
+		project := projects[completion.PID]
+		view := completionView{ProjectCompletion: completion, ProjectName: project.Name}
+		if supervisor, ok := participants[project.CreatorID]; ok {
+			view.SupervisorName, _ = supervisor["name"].(string)
+		}
+		if includeStudent {
+			view.Student = participants[completion.UID]
+		}
+		if review, ok := reviews[completion.ID]; ok {
+			view.Review = &review
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+// sendCompletionEmail tells a student their work on a project was marked completed
+func sendCompletionEmail(project models.Projects, completion models.ProjectCompletion, supervisorName string) {
+	go func() {
+		var student models.User
+		if err := config.DB.Where("uid = ?", completion.UID).First(&student).Error; err != nil {
+			log.Printf("Failed to fetch student %s for completion email: %v", completion.UID, err)
+			return
+		}
+
+		message, err := utils.NewTemplateEmail(student.Email, "project_completion", loadEmailLocale(student.Uid), echo.Map{
+			"Name":            student.Name,
+			"SupervisorName":  supervisorName,
+			"ProjectName":     project.Name,
+			"Evaluation":      completion.Evaluation,
+			"CertificateCode": completion.CertificateCode,
+			"VerifyURL":       certificateVerifyURL(completion.CertificateCode),
+			"CompletionsURL":  fmt.Sprintf("%s/student/completions", os.Getenv("FRONTEND_URL")),
+		})
+		if err != nil {
+			log.Printf("Failed to build completion email to %s: %v", student.Email, err)
+			return
+		}
+
+		dispatchEmail(models.NotificationProjectCompleted, student.Uid, message, fmt.Sprintf("Completed %s", project.Name))
+	}()
+}
+
+// CompleteProjectMember closes a working user's participation with an evaluation and rating, issues their
+// certificate and adds the project to their verified platform projects
+func CompleteProjectMember(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findReviewableProject(c.Param("id"), userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		UID        string `json:"uid"`
+		Rating     int    `json:"rating"`
+		Evaluation string `json:"evaluation"`
+		Role       string `json:"role"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if !isValidRating(requestBody.Rating) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Rating must be between 1 and 5"})
+	}
+	evaluation := strings.TrimSpace(requestBody.Evaluation)
+	if evaluation == "" || len([]rune(evaluation)) > evaluationMaxLength {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Evaluation is required and cannot be longer than %d characters", evaluationMaxLength)})
+	}
+	role := strings.TrimSpace(requestBody.Role)
+	if len([]rune(role)) > completionRoleMaxLen {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Role cannot be longer than %d characters", completionRoleMaxLen)})
+	}
+
+	// A revoked completion is reissued in place, even though completing removed the student from the
+	// working users; an active one cannot be completed twice
+	var completion models.ProjectCompletion
+	reissue := false
+	if err := config.DB.Where("p_id = ? AND uid = ?", project.ProjectID, requestBody.UID).First(&completion).Error; err == nil {
+		if completion.RevokedAt == nil {
+			return c.JSON(http.StatusConflict, echo.Map{"error": "This student has already completed the project"})
+		}
+		reissue = true
+	}
+	if !reissue && !slices.Contains(project.WorkingUsers, requestBody.UID) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Only working users of the project can be marked as completed"})
+	}
+
+	code, err := utils.GenerateCertificateCode()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to generate certificate code"})
+	}
+
+	completion.PID = project.ProjectID
+	completion.UID = requestBody.UID
+	completion.Role = role
+	completion.Rating = requestBody.Rating
+	completion.Evaluation = evaluation
+	completion.Hours = loadApprovedHours(project.ProjectID, requestBody.UID)
+	completion.CertificateCode = code
+	completion.CompletedBy = userData.GetUID()
+	completion.CompletedAt = time.Now()
+	completion.RevokedAt = nil
+	completion.RevokeReason = ""
+
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Save(&completion).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to record completion"})
+	}
+
+	// The student's work is over; their accepted application stays as it is
+	if err := tx.Exec(
+		"UPDATE projects SET working_users = array_remove(working_users, ?), updated_at = NOW() WHERE project_id = ?",
+		requestBody.UID, project.ProjectID,
+	).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update project members"})
+	}
+
+	if err := tx.Exec(
+		"UPDATE students SET platform_projects = array_append(COALESCE(platform_projects, '{}'), ?) WHERE uid = ? AND NOT (? = ANY(COALESCE(platform_projects, '{}')))",
+		project.ID, requestBody.UID, project.ID,
+	).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update student profile"})
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
+	}
+
+	supervisorName := ""
+	var supervisor models.User
+	if err := config.DB.Select("name").Where("uid = ?", project.CreatorID).First(&supervisor).Error; err == nil {
+		supervisorName = supervisor.Name
+	}
+
+	createNotifications([]models.Notification{{
+		RecipientUID: completion.UID,
+		Type:         models.NotificationProjectCompleted,
+		Title:        fmt.Sprintf("You completed %s", project.Name),
+		Body:         "Your completion certificate is ready. Please take a minute to review the project.",
+		PID:          project.ProjectID,
+	}})
+	sendCompletionEmail(project, completion, supervisorName)
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message":    "Student marked as completed",
+		"completion": buildCompletionViews([]models.ProjectCompletion{completion}, true)[0],
+	})
+}
+
+// GetProjectCompletions lists the completions of a project with each student's review
+func GetProjectCompletions(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findReviewableProject(c.Param("id"), userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	var completions []models.ProjectCompletion
+	if err := config.DB.Where("p_id = ?", project.ProjectID).Order("completed_at DESC").Find(&completions).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch completions"})
+	}
+
+	views := buildCompletionViews(completions, true)
+	return c.JSON(http.StatusOK, echo.Map{
+		"completions": views,
+		"count":       len(views),
+	})
+}
+
+// UpdateProjectCompletion corrects the evaluation, rating or role of a completion
+func UpdateProjectCompletion(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findReviewableProject(c.Param("id"), userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	var completion models.ProjectCompletion
+	if err := config.DB.Where("id = ? AND p_id = ?", c.Param("completionId"), project.ProjectID).First(&completion).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Completion not found"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Rating     *int    `json:"rating"`
+		Evaluation *string `json:"evaluation"`
+		Role       *string `json:"role"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	updates := make(map[string]interface{})
+	if requestBody.Rating != nil {
+		if !isValidRating(*requestBody.Rating) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Rating must be between 1 and 5"})
+		}
+		updates["rating"] = *requestBody.Rating
+	}
+	if requestBody.Evaluation != nil {
+		evaluation := strings.TrimSpace(*requestBody.Evaluation)
+		if evaluation == "" || len([]rune(evaluation)) > evaluationMaxLength {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Evaluation is required and cannot be longer than %d characters", evaluationMaxLength)})
+		}
+		updates["evaluation"] = evaluation
+	}
+	if requestBody.Role != nil {
+		role := strings.TrimSpace(*requestBody.Role)
+		if len([]rune(role)) > completionRoleMaxLen {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Role cannot be longer than %d characters", completionRoleMaxLen)})
+		}
+		updates["role"] = role
+	}
+
+	if len(updates) > 0 {
+		if err := config.DB.Model(&completion).Updates(updates).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update completion"})
+		}
+		config.DB.First(&completion, completion.ID)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":    "Completion updated",
+		"completion": buildCompletionViews([]models.ProjectCompletion{completion}, true)[0],
+	})
+}
+
+// RevokeProjectCompletion withdraws a completion so its certificate no longer verifies
+func RevokeProjectCompletion(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findReviewableProject(c.Param("id"), userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	var completion models.ProjectCompletion
+	if err := config.DB.Where("id = ? AND p_id = ?", c.Param("completionId"), project.ProjectID).First(&completion).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Completion not found"})
+	}
+	if completion.RevokedAt != nil {
+		return c.JSON(http.StatusConflict, echo.Map{"error": "Completion is already revoked"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Reason string `json:"reason"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	reason := strings.TrimSpace(requestBody.Reason)
+	if reason == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "A reason is required to revoke a completion"})
+	}
+
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Model(&completion).Updates(map[string]interface{}{"revoked_at": time.Now(), "revoke_reason": reason}).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to revoke completion"})
+	}
+
+	// The project no longer counts as verified work on the student's profile
+	if err := tx.Exec(
+		"UPDATE students SET platform_projects = array_remove(platform_projects, ?) WHERE uid = ?",
+		project.ID, completion.UID,
+	).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update student profile"})
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
+	}
+	config.DB.First(&completion, completion.ID)
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":    "Completion revoked",
+		"completion": completion,
+	})
+}
+
+// GetMyCompletions lists the student's completed projects with their evaluations and own reviews
+func GetMyCompletions(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	var completions []models.ProjectCompletion
+	if err := config.DB.Where("uid = ?", userData.GetUID()).Order("completed_at DESC").Find(&completions).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch completions"})
+	}
+
+	views := buildCompletionViews(completions, false)
+	return c.JSON(http.StatusOK, echo.Map{
+		"completions": views,
+		"count":       len(views),
+	})
+}
+
+// ReviewCompletedProject lets a student rate and review a project they completed; reviewing again replaces it
+func ReviewCompletedProject(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	var completion models.ProjectCompletion
+	if err := config.DB.Where("id = ? AND uid = ?", c.Param("completionId"), userData.GetUID()).First(&completion).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Completion not found"})
+	}
+	if completion.RevokedAt != nil {
+		return c.JSON(http.StatusConflict, echo.Map{"error": "This completion has been revoked"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Rating  int    `json:"rating"`
+		Comment string `json:"comment"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if !isValidRating(requestBody.Rating) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Rating must be between 1 and 5"})
+	}
+	comment := strings.TrimSpace(requestBody.Comment)
+	if len([]rune(comment)) > projectReviewMaxLength {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Review cannot be longer than %d characters", projectReviewMaxLength)})
+	}
+
+	var review models.ProjectReview
+	config.DB.Where("completion_id = ?", completion.ID).First(&review)
+	review.CompletionID = completion.ID
+	review.PID = completion.PID
+	review.UID = completion.UID
+	review.Rating = requestBody.Rating
+	review.Comment = comment
+	if err := config.DB.Save(&review).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save review"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Review saved",
+		"review":  review,
+	})
+}
+
+// DownloadCompletionCertificate returns the completion certificate as a PDF to the student or the project's owners
+func DownloadCompletionCertificate(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	var completion models.ProjectCompletion
+	if err := config.DB.Where("id = ?", c.Param("completionId")).First(&completion).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Completion not found"})
+	}
+
+	var project models.Projects
+	if err := config.DB.Unscoped().Where("project_id = ?", completion.PID).First(&project).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	}
+	if completion.UID != userData.GetUID() && !canModerateProject(project, userData.GetUID()) {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Completion not found"})
+	}
+	if completion.RevokedAt != nil {
+		return c.JSON(http.StatusGone, echo.Map{"error": "This certificate has been revoked"})
+	}
+
+	view := buildCompletionViews([]models.ProjectCompletion{completion}, true)[0]
+	studentName, _ := view.Student["name"].(string)
+
+	pdf := utils.BuildCompletionCertificate(utils.CompletionCertificate{
+		StudentName:    studentName,
+		ProjectName:    view.ProjectName,
+		SupervisorName: view.SupervisorName,
+		Role:           completion.Role,
+		Hours:          completion.Hours,
+		CompletedAt:    completion.CompletedAt,
+		Code:           completion.CertificateCode,
+		VerifyURL:      certificateVerifyURL(completion.CertificateCode),
+	})
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="certificate-%s.pdf"`, completion.CertificateCode))
+	return c.Blob(http.StatusOK, "application/pdf", pdf)
+}
+
+// VerifyCertificate publicly confirms a completion certificate from its code, without the private evaluation
+func VerifyCertificate(c echo.Context) error {
+	code := strings.ToUpper(strings.TrimSpace(c.Param("code")))
+
+	var completion models.ProjectCompletion
+	if err := config.DB.Where("certificate_code = ?", code).First(&completion).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"valid": false, "error": "No certificate matches this code"})
+	}
+
+	view := buildCompletionViews([]models.ProjectCompletion{completion}, true)[0]
+	studentName, _ := view.Student["name"].(string)
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"valid":          completion.RevokedAt == nil,
+		"code":           completion.CertificateCode,
+		"studentName":    studentName,
+		"projectName":    view.ProjectName,
+		"supervisorName": view.SupervisorName,
+		"role":           completion.Role,
+		"hours":          completion.Hours,
+		"completedAt":    completion.CompletedAt,
+		"revokedAt":      completion.RevokedAt,
+	})
+}
@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	projectMatchInterval = 7 * 24 * time.Hour // How often a subscribed student gets the email
+	projectMatchLimit    = 5                  // Matches per email
+	projectMatchMinScore = 20.0               // Same floor as GetRecommendedProjects
+)
+
+// projectMatchEmailEntry is one project in the weekly matches email
+type projectMatchEmailEntry struct {
+	Name      string
+	Professor string
+	Score     int
+	Reasons   []string
+	URL       string
+}
+
+// projectMatchUnsubscribeURL is the link that turns the weekly email off without logging in
+func projectMatchUnsubscribeURL(token string) string {
+	return fmt.Sprintf("%s/unsubscribe?token=%s", os.Getenv("FRONTEND_URL"), token)
+}
+
+// projectMatchOneClickUnsubscribeURL is the backend endpoint mail clients POST to for RFC 8058 one-click
+// unsubscribe; empty when BACKEND_URL is not configured
+func projectMatchOneClickUnsubscribeURL(token string) string {
+	backendURL := os.Getenv("BACKEND_URL")
+	if backendURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/unsubscribe/project-matches/%s", strings.TrimSuffix(backendURL, "/"), token)
+}
+
+// compileProjectMatches scores the projects created since the student's last email and returns the best
+// ones they have not been sent before
+func compileProjectMatches(subscription models.ProjectMatchSubscription, until time.Time) ([]RecommendedProject, error) {
+	match, err := loadStudentMatchContext(subscription.UserUID)
+	if err != nil {
+		// No student profile yet, so nothing can be matched
+		return nil, nil
+	}
+
+	var projects []models.Projects
+	if err := config.DB.
+		Where("is_active = ? AND creator_id != ? AND created_at > ? AND created_at <= ?", true, subscription.UserUID, subscription.LastSentAt, until).
+		Where("project_id NOT IN (?)", config.DB.Model(&models.ProjectMatchSent{}).Select("p_id").Where("user_uid = ?", subscription.UserUID)).
+		Find(&projects).Error; err != nil {
+		return nil, err
+	}
+
+	var matches []RecommendedProject
+	creatorIDs := make([]string, 0, len(projects))
+	for _, project := range projects {
+		if !match.isCandidate(project, until) {
+			continue
+		}
+		score, reasons := calculateMatchScore(match.student, project, match.hasPreferences, match.preferences, match.recentAppliedProjects)
+		if score < projectMatchMinScore {
+			continue
+		}
+		matches = append(matches, RecommendedProject{Projects: project, MatchScore: score, MatchReasons: reasons})
+		creatorIDs = append(creatorIDs, project.CreatorID)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].MatchScore > matches[j].MatchScore })
+	if len(matches) > projectMatchLimit {
+		matches = matches[:projectMatchLimit]
+	}
+
+	if len(matches) > 0 {
+		var creators []models.User
+		config.DB.Select("uid, name").Where("uid IN ?", creatorIDs).Find(&creators)
+		creatorMap := make(map[string]models.User, len(creators))
+		for _, creator := range creators {
+			creatorMap[creator.Uid] = creator
+		}
+		for i := range matches {
+			matches[i].User = creatorMap[matches[i].CreatorID]
+		}
+	}
+	return matches, nil
+}
+
+// sendProjectMatchDigest emails one student their new matches and records them as sent.
+// The window only advances once the email is out, so a failed send is retried on the next run.
+func sendProjectMatchDigest(subscription models.ProjectMatchSubscription, now time.Time) error {
+	matches, err := compileProjectMatches(subscription, now)
+	if err != nil {
+		return err
+	}
+
+	advance := func(tx *gorm.DB) error {
+		return tx.Model(&models.ProjectMatchSubscription{}).Where("id = ?", subscription.ID).Update("last_sent_at", now).Error
+	}
+
+	// Nothing new this week; move the window on without emailing
+	if len(matches) == 0 {
+		return advance(config.DB)
+	}
+
+	var student models.User
+	if err := config.DB.Where("uid = ?", subscription.UserUID).First(&student).Error; err != nil {
+		return fmt.Errorf("failed to fetch student: %w", err)
+	}
+
+	entries := make([]projectMatchEmailEntry, 0, len(matches))
+	for _, match := range matches {
+		entries = append(entries, projectMatchEmailEntry{
+			Name:      match.Name,
+			Professor: match.User.Name,
+			Score:     int(math.Round(match.MatchScore)),
+			Reasons:   match.MatchReasons,
+			URL:       fmt.Sprintf("%s/project/%s", os.Getenv("FRONTEND_URL"), match.ProjectID),
+		})
+	}
+
+	unsubscribeURL := projectMatchUnsubscribeURL(subscription.UnsubscribeToken)
+	message, err := utils.NewTemplateEmail(student.Email, "project_matches", loadEmailLocale(student.Uid), echo.Map{
+		"Name":           student.Name,
+		"Matches":        entries,
+		"ProjectsURL":    fmt.Sprintf("%s/student/recommendations", os.Getenv("FRONTEND_URL")),
+		"UnsubscribeURL": unsubscribeURL,
+	})
+	if err != nil {
+		return err
+	}
+	message.Headers = map[string]string{"List-Unsubscribe": "<" + unsubscribeURL + ">"}
+	if oneClickURL := projectMatchOneClickUnsubscribeURL(subscription.UnsubscribeToken); oneClickURL != "" {
+		message.Headers["List-Unsubscribe"] = "<" + oneClickURL + ">"
+		message.Headers["List-Unsubscribe-Post"] = "List-Unsubscribe=One-Click"
+	}
+
+	if err := utils.SendEmail(utils.LoadEmailConfig(), message); err != nil {
+		return err
+	}
+
+	sent := make([]models.ProjectMatchSent, 0, len(matches))
+	for _, match := range matches {
+		sent = append(sent, models.ProjectMatchSent{UserUID: subscription.UserUID, PID: match.ProjectID, MatchScore: match.MatchScore, SentAt: now})
+	}
+
+	tx := config.DB.Begin()
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&sent).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := advance(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// sendDueProjectMatchDigests emails every subscribed student whose last email is a week old
+func sendDueProjectMatchDigests() {
+	now := time.Now()
+
+	var subscriptions []models.ProjectMatchSubscription
+	if err := config.DB.Where("enabled = ? AND last_sent_at <= ?", true, now.Add(-projectMatchInterval)).Find(&subscriptions).Error; err != nil {
+		log.Printf("Failed to load project match subscriptions: %v", err)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if err := sendProjectMatchDigest(subscription, now); err != nil {
+			log.Printf("Failed to send project matches to %s: %v", subscription.UserUID, err)
+		}
+	}
+}
+
+// StartProjectMatchDigests starts a goroutine that sends the weekly new-matching-projects email
+func StartProjectMatchDigests() {
+	ticker := time.NewTicker(time.Hour)
+	go func() {
+		for range ticker.C {
+			sendDueProjectMatchDigests()
+		}
+	}()
+}
+
+// GetProjectMatchSubscription returns whether the student receives the weekly matches email
+func GetProjectMatchSubscription(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	var subscription models.ProjectMatchSubscription
+	if err := config.DB.Where("user_uid = ?", userData.GetUID()).First(&subscription).Error; err != nil {
+		return c.JSON(http.StatusOK, echo.Map{"enabled": false})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"enabled":    subscription.Enabled,
+		"lastSentAt": subscription.LastSentAt,
+	})
+}
+
+// UpdateProjectMatchSubscription opts the student in to or out of the weekly matches email
+func UpdateProjectMatchSubscription(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Parse request body
+	var requestBody struct {
+		Enabled *bool `json:"enabled"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil || requestBody.Enabled == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "enabled is required"})
+	}
+
+	now := time.Now()
+	var subscription models.ProjectMatchSubscription
+	if err := config.DB.Where("user_uid = ?", userData.GetUID()).First(&subscription).Error; err != nil {
+		token, err := utils.GenerateUnsubscribeToken()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to generate unsubscribe token"})
+		}
+		subscription = models.ProjectMatchSubscription{
+			UserUID:          userData.GetUID(),
+			Enabled:          *requestBody.Enabled,
+			UnsubscribeToken: token,
+			LastSentAt:       now,
+		}
+		if err := config.DB.Create(&subscription).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save subscription"})
+		}
+	} else if subscription.Enabled != *requestBody.Enabled {
+		updates := map[string]interface{}{"enabled": *requestBody.Enabled}
+		// Re-subscribing starts a fresh window instead of catching up on every project posted while away
+		if *requestBody.Enabled {
+			updates["last_sent_at"] = now
+		}
+		if err := config.DB.Model(&subscription).Updates(updates).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save subscription"})
+		}
+	}
+
+	message := "Unsubscribed from weekly project matches"
+	if *requestBody.Enabled {
+		message = "Subscribed to weekly project matches"
+	}
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":    message,
+		"enabled":    subscription.Enabled,
+		"lastSentAt": subscription.LastSentAt,
+	})
+}
+
+// UnsubscribeProjectMatches turns the weekly matches email off from the link in the email; the token authenticates
+func UnsubscribeProjectMatches(c echo.Context) error {
+	token := c.Param("token")
+
+	var subscription models.ProjectMatchSubscription
+	if err := config.DB.Where("unsubscribe_token = ?", token).First(&subscription).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Unsubscribe link is invalid"})
+	}
+
+	if subscription.Enabled {
+		if err := config.DB.Model(&subscription).Update("enabled", false).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to unsubscribe"})
+		}
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "You will no longer receive weekly project matches"})
+}
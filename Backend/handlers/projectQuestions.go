@@ -0,0 +1,523 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	projectQuestionMaxLength = 1000 // Characters per question
+	projectAnswerMaxLength   = 5000 // Characters per answer
+	projectQuestionDailyCap  = 5    // Questions a student may ask on one project per day
+)
+
+// projectQACounts summarises a project's Q&A board for GetProject
+type projectQACounts struct {
+	Questions int64 `json:"questions"`
+	Answered  int64 `json:"answered"`
+}
+
+// projectAnswerView is an answer as shown on the board
+type projectAnswerView struct {
+	models.ProjectAnswer
+	AuthorName string `json:"authorName"`
+}
+
+// projectQuestionView is a question as shown to one viewer, with its answers
+type projectQuestionView struct {
+	models.ProjectQuestion
+	AskedByMe bool                `json:"askedByMe"`
+	Upvoted   bool                `json:"upvoted"`
+	Answers   []projectAnswerView `json:"answers"`
+}
+
+// canModerateProject reports whether the user owns or reviews the project and so answers and moderates its board
+func canModerateProject(project models.Projects, uid string) bool {
+	return project.CreatorID == uid || slices.Contains(project.Reviewers, uid)
+}
+
+// loadProjectQACounts counts the visible questions of a project and how many have a visible answer
+func loadProjectQACounts(projectID string) projectQACounts {
+	var counts projectQACounts
+	config.DB.Model(&models.ProjectQuestion{}).Where("p_id = ? AND hidden = ?", projectID, false).Count(&counts.Questions)
+	config.DB.Model(&models.ProjectQuestion{}).
+		Where("p_id = ? AND hidden = ?", projectID, false).
+		Where("EXISTS (SELECT 1 FROM project_answers WHERE project_answers.question_id = project_questions.id AND project_answers.hidden = ?)", false).
+		Count(&counts.Answered)
+	return counts
+}
+
+// findBoardQuestion loads the question in the :questionId path parameter of the project
+func findBoardQuestion(c echo.Context, projectID string) (models.ProjectQuestion, error) {
+	var question models.ProjectQuestion
+	err := config.DB.Where("id = ? AND p_id = ?", c.Param("questionId"), projectID).First(&question).Error
+	return question, err
+}
+
+// findBoardAnswer loads the answer in the :answerId path parameter of the question
+func findBoardAnswer(c echo.Context, question models.ProjectQuestion) (models.ProjectAnswer, error) {
+	var answer models.ProjectAnswer
+	err := config.DB.Where("id = ? AND question_id = ?", c.Param("answerId"), question.ID).First(&answer).Error
+	return answer, err
+}
+
+// GetProjectQuestions returns a page of a project's Q&A board. Questions with a pinned answer come first,
+// then the most upvoted. Hidden posts are only shown to the project's owners.
+func GetProjectQuestions(c echo.Context) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+	uid := userData.GetUID()
+
+	var project models.Projects
+	if err := config.DB.Where("project_id = ?", projectID).First(&project).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	}
+	moderator := canModerateProject(project, uid)
+
+	// Get pagination parameters
+	page := 1
+	pageSize := 20
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if pageSizeParam := c.QueryParam("pageSize"); pageSizeParam != "" {
+		if ps, err := strconv.Atoi(pageSizeParam); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+
+	query := config.DB.Model(&models.ProjectQuestion{}).Where("p_id = ?", projectID)
+	if !moderator {
+		query = query.Where("hidden = ?", false)
+	}
+
+	// Get total count before pagination
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to count questions"})
+	}
+
+	// Apply pagination
+	var questions []models.ProjectQuestion
+	offset := (page - 1) * pageSize
+	if err := query.Offset(offset).Limit(pageSize).
+		Order(clause.Expr{SQL: "EXISTS (SELECT 1 FROM project_answers WHERE project_answers.question_id = project_questions.id AND project_answers.pinned AND NOT project_answers.hidden) DESC"}).
+		Order("upvotes DESC, created_at DESC").
+		Find(&questions).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch questions"})
+	}
+
+	questionIDs := make([]uint, 0, len(questions))
+	for _, question := range questions {
+		questionIDs = append(questionIDs, question.ID)
+	}
+
+	answersByQuestion := make(map[uint][]projectAnswerView, len(questions))
+	upvoted := make(map[uint]bool)
+	if len(questionIDs) > 0 {
+		answerQuery := config.DB.Where("question_id IN ?", questionIDs)
+		if !moderator {
+			answerQuery = answerQuery.Where("hidden = ?", false)
+		}
+		var answers []models.ProjectAnswer
+		if err := answerQuery.Order("pinned DESC, created_at ASC").Find(&answers).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch answers"})
+		}
+
+		authorUIDs := make([]string, 0, len(answers))
+		for _, answer := range answers {
+			authorUIDs = append(authorUIDs, answer.AuthorUID)
+		}
+		var authors []models.User
+		if len(authorUIDs) > 0 {
+			config.DB.Select("uid, name").Where("uid IN ?", authorUIDs).Find(&authors)
+		}
+		authorNames := make(map[string]string, len(authors))
+		for _, author := range authors {
+			authorNames[author.Uid] = author.Name
+		}
+
+		for _, answer := range answers {
+			answersByQuestion[answer.QuestionID] = append(answersByQuestion[answer.QuestionID],
+				projectAnswerView{ProjectAnswer: answer, AuthorName: authorNames[answer.AuthorUID]})
+		}
+
+		var votedIDs []uint
+		config.DB.Model(&models.ProjectQuestionVote{}).Where("question_id IN ? AND user_uid = ?", questionIDs, uid).Pluck("question_id", &votedIDs)
+		for _, id := range votedIDs {
+			upvoted[id] = true
+		}
+	}
+
+	views := make([]projectQuestionView, 0, len(questions))
+	for _, question := range questions {
+		answers := answersByQuestion[question.ID]
+		if answers == nil {
+			answers = []projectAnswerView{}
+		}
+		views = append(views, projectQuestionView{
+			ProjectQuestion: question,
+			AskedByMe:       question.AuthorUID == uid,
+			Upvoted:         upvoted[question.ID],
+			Answers:         answers,
+		})
+	}
+
+	totalPages := int((totalCount + int64(pageSize) - 1) / int64(pageSize))
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"questions":  views,
+		"count":      len(views),
+		"total":      totalCount,
+		"page":       page,
+		"pageSize":   pageSize,
+		"totalPages": totalPages,
+		"canAnswer":  moderator,
+	})
+}
+
+// AskProjectQuestion posts a student's question to a project's Q&A board
+func AskProjectQuestion(c echo.Context) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Parse request body
+	var requestBody struct {
+		Body string `json:"body"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	body := strings.TrimSpace(requestBody.Body)
+	if body == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Question cannot be empty"})
+	}
+	if len([]rune(body)) > projectQuestionMaxLength {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Question cannot be longer than %d characters", projectQuestionMaxLength)})
+	}
+
+	var project models.Projects
+	if err := config.DB.Where("project_id = ?", projectID).First(&project).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	}
+	if !project.IsActive {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "This project is not accepting questions"})
+	}
+
+	var askedToday int64
+	config.DB.Model(&models.ProjectQuestion{}).
+		Where("p_id = ? AND author_uid = ? AND created_at > ?", projectID, userData.GetUID(), time.Now().Add(-24*time.Hour)).
+		Count(&askedToday)
+	if askedToday >= projectQuestionDailyCap {
+		return c.JSON(http.StatusTooManyRequests, echo.Map{"error": fmt.Sprintf("You can ask at most %d questions per project per day", projectQuestionDailyCap)})
+	}
+
+	question := models.ProjectQuestion{
+		PID:       projectID,
+		AuthorUID: userData.GetUID(),
+		Body:      body,
+	}
+	if err := config.DB.Create(&question).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to post question"})
+	}
+
+	createNotifications([]models.Notification{{
+		RecipientUID: project.CreatorID,
+		Type:         models.NotificationQuestionAsked,
+		Title:        fmt.Sprintf("New question on %s", project.Name),
+		Body:         body,
+		PID:          project.ProjectID,
+	}})
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message":  "Question posted",
+		"question": projectQuestionView{ProjectQuestion: question, AskedByMe: true, Answers: []projectAnswerView{}},
+	})
+}
+
+// AnswerProjectQuestion lets a project owner answer a question on the board
+func AnswerProjectQuestion(c echo.Context) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findReviewableProject(projectID, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	question, err := findBoardQuestion(c, projectID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Question not found"})
+	}
+	if question.Hidden {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Hidden questions cannot be answered"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Body   string `json:"body"`
+		Pinned bool   `json:"pinned"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	body := strings.TrimSpace(requestBody.Body)
+	if body == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Answer cannot be empty"})
+	}
+	if len([]rune(body)) > projectAnswerMaxLength {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Answer cannot be longer than %d characters", projectAnswerMaxLength)})
+	}
+
+	answer := models.ProjectAnswer{
+		QuestionID: question.ID,
+		PID:        projectID,
+		AuthorUID:  userData.GetUID(),
+		Body:       body,
+		Pinned:     requestBody.Pinned,
+	}
+	if err := config.DB.Create(&answer).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to post answer"})
+	}
+
+	createNotifications([]models.Notification{{
+		RecipientUID: question.AuthorUID,
+		Type:         models.NotificationQuestionAnswered,
+		Title:        fmt.Sprintf("Your question on %s was answered", project.Name),
+		Body:         body,
+		PID:          project.ProjectID,
+	}})
+
+	var author models.User
+	config.DB.Select("uid, name").Where("uid = ?", userData.GetUID()).First(&author)
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Answer posted",
+		"answer":  projectAnswerView{ProjectAnswer: answer, AuthorName: author.Name},
+	})
+}
+
+// PinProjectAnswer pins or unpins an answer, which lifts its question to the top of the board
+func PinProjectAnswer(c echo.Context) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	if _, err := findReviewableProject(projectID, userData.GetUID()); err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	question, err := findBoardQuestion(c, projectID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Question not found"})
+	}
+	answer, err := findBoardAnswer(c, question)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Answer not found"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Pinned *bool `json:"pinned"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil || requestBody.Pinned == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "pinned is required"})
+	}
+
+	if err := config.DB.Model(&answer).Update("pinned", *requestBody.Pinned).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update answer"})
+	}
+	config.DB.First(&answer, answer.ID)
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Answer updated",
+		"answer":  answer,
+	})
+}
+
+// setBoardPostHidden hides or restores a question or answer for a project owner
+func setBoardPostHidden(post interface{}, hidden bool) error {
+	updates := map[string]interface{}{"hidden": hidden, "hidden_at": nil}
+	if hidden {
+		updates["hidden_at"] = time.Now()
+	}
+	return config.DB.Model(post).Updates(updates).Error
+}
+
+// HideProjectQuestion hides an abusive question from everyone but the project's owners, or restores it
+func HideProjectQuestion(c echo.Context) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	if _, err := findReviewableProject(projectID, userData.GetUID()); err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	question, err := findBoardQuestion(c, projectID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Question not found"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Hidden *bool `json:"hidden"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil || requestBody.Hidden == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "hidden is required"})
+	}
+
+	if err := setBoardPostHidden(&question, *requestBody.Hidden); err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update question"})
+	}
+	config.DB.First(&question, question.ID)
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":  "Question updated",
+		"question": question,
+	})
+}
+
+// HideProjectAnswer hides an answer from everyone but the project's owners, or restores it
+func HideProjectAnswer(c echo.Context) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	if _, err := findReviewableProject(projectID, userData.GetUID()); err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	question, err := findBoardQuestion(c, projectID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Question not found"})
+	}
+	answer, err := findBoardAnswer(c, question)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Answer not found"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Hidden *bool `json:"hidden"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil || requestBody.Hidden == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "hidden is required"})
+	}
+
+	if err := setBoardPostHidden(&answer, *requestBody.Hidden); err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update answer"})
+	}
+	config.DB.First(&answer, answer.ID)
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Answer updated",
+		"answer":  answer,
+	})
+}
+
+// changeQuestionVote adds or removes the user's upvote and keeps the question's counter in step.
+// The counter only moves when the vote row actually changes, so repeated clicks are harmless.
+func changeQuestionVote(question models.ProjectQuestion, uid string, upvote bool) (int, error) {
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var result *gorm.DB
+	delta := 1
+	if upvote {
+		result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ProjectQuestionVote{QuestionID: question.ID, UserUID: uid})
+	} else {
+		result = tx.Where("question_id = ? AND user_uid = ?", question.ID, uid).Delete(&models.ProjectQuestionVote{})
+		delta = -1
+	}
+	if result.Error != nil {
+		tx.Rollback()
+		return 0, result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		if err := tx.Model(&models.ProjectQuestion{}).Where("id = ?", question.ID).
+			Update("upvotes", gorm.Expr("upvotes + ?", delta)).Error; err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	var upvotes int
+	if err := tx.Model(&models.ProjectQuestion{}).Where("id = ?", question.ID).Select("upvotes").Scan(&upvotes).Error; err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	return upvotes, tx.Commit().Error
+}
+
+// UpvoteProjectQuestion adds the user's upvote to a question
+func UpvoteProjectQuestion(c echo.Context) error {
+	return voteOnProjectQuestion(c, true)
+}
+
+// RemoveProjectQuestionUpvote takes the user's upvote back
+func RemoveProjectQuestionUpvote(c echo.Context) error {
+	return voteOnProjectQuestion(c, false)
+}
+
+// voteOnProjectQuestion handles both vote routes
+func voteOnProjectQuestion(c echo.Context, upvote bool) error {
+	projectID := c.Param("id")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	question, err := findBoardQuestion(c, projectID)
+	if err != nil || question.Hidden {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Question not found"})
+	}
+	if upvote && question.AuthorUID == userData.GetUID() {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "You cannot upvote your own question"})
+	}
+
+	upvotes, err := changeQuestionVote(question, userData.GetUID(), upvote)
+	if err != nil {
+		log.Printf("Failed to record vote on question %d: %v", question.ID, err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to record vote"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"questionId": question.ID,
+		"upvoted":    upvote,
+		"upvotes":    upvotes,
+	})
+}
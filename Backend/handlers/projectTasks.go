@@ -0,0 +1,816 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	taskTitleMaxLength   = 200  // Characters per milestone or task title
+	taskCommentMaxLength = 5000 // Characters per comment
+)
+
+// projectTaskView is a task as shown on the project's board
+type projectTaskView struct {
+	models.ProjectTask
+	AssigneeName string `json:"assigneeName"`
+	Overdue      bool   `json:"overdue"`
+	CommentCount int64  `json:"commentCount"`
+}
+
+// projectMilestoneView is a milestone with the progress of its tasks
+type projectMilestoneView struct {
+	models.ProjectMilestone
+	TaskCount    int64 `json:"taskCount"`
+	DoneCount    int64 `json:"doneCount"`
+	OverdueCount int64 `json:"overdueCount"`
+}
+
+// memberProgress is one working user's row in the progress view
+type memberProgress struct {
+	UID            string     `json:"uid"`
+	Name           string     `json:"name"`
+	Assigned       int64      `json:"assigned"`
+	Done           int64      `json:"done"`
+	InProgress     int64      `json:"inProgress"`
+	Blocked        int64      `json:"blocked"`
+	Overdue        int64      `json:"overdue"`
+	CompletionRate float64    `json:"completionRate"` // Percentage of assigned tasks that are done
+	LastActivityAt *time.Time `json:"lastActivityAt"`
+	Behind         bool       `json:"behind"` // Has at least one overdue task
+}
+
+// validTaskStatuses lists every status a task can be in
+var validTaskStatuses = []string{models.TaskTodo, models.TaskInProgress, models.TaskBlocked, models.TaskDone}
+
+// isValidTaskStatus checks a status against validTaskStatuses
+func isValidTaskStatus(status string) bool {
+	return slices.Contains(validTaskStatuses, status)
+}
+
+// taskStatusUpdates returns the columns to update when a task moves to status; done stamps the completion time
+func taskStatusUpdates(status string) map[string]interface{} {
+	updates := map[string]interface{}{"status": status, "completed_at": nil}
+	if status == models.TaskDone {
+		updates["completed_at"] = time.Now()
+	}
+	return updates
+}
+
+// startOfToday is midnight UTC today; tasks due before it are overdue
+func startOfToday() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+// parseDueDate parses a YYYY-MM-DD due date; an empty value clears it
+func parseDueDate(value string) (*time.Time, bool) {
+	if value == "" {
+		return nil, true
+	}
+	dueDate, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, false
+	}
+	return &dueDate, true
+}
+
+// isTaskOverdue reports whether an unfinished task is past its due date
+func isTaskOverdue(task models.ProjectTask, today time.Time) bool {
+	return task.Status != models.TaskDone && task.DueDate != nil && task.DueDate.Before(today)
+}
+
+// loadMemberProject loads the project in the :id path parameter if the user is a member of it,
+// and reports whether they own or review it
+func loadMemberProject(c echo.Context, uid string) (models.Projects, bool, error) {
+	var project models.Projects
+	if err := config.DB.Where("project_id = ?", c.Param("id")).First(&project).Error; err != nil {
+		return project, false, err
+	}
+	if !isProjectMember(project, uid) {
+		return project, false, fmt.Errorf("user %s is not a member of project %s", uid, project.ProjectID)
+	}
+	return project, canModerateProject(project, uid), nil
+}
+
+// findProjectMilestone loads a milestone of the project by ID
+func findProjectMilestone(projectID string, milestoneID interface{}) (models.ProjectMilestone, error) {
+	var milestone models.ProjectMilestone
+	err := config.DB.Where("id = ? AND p_id = ?", milestoneID, projectID).First(&milestone).Error
+	return milestone, err
+}
+
+// findProjectTask loads the task in the :taskId path parameter of the project
+func findProjectTask(c echo.Context, projectID string) (models.ProjectTask, error) {
+	var task models.ProjectTask
+	err := config.DB.Where("id = ? AND p_id = ?", c.Param("taskId"), projectID).First(&task).Error
+	return task, err
+}
+
+// notifyTaskAssignee tells a working user they were given a task, unless they assigned it to themselves
+func notifyTaskAssignee(project models.Projects, task models.ProjectTask, assignerUID string) {
+	if task.AssigneeUID == "" || task.AssigneeUID == assignerUID {
+		return
+	}
+	body := fmt.Sprintf("You have been assigned \"%s\" on %s.", task.Title, project.Name)
+	if task.DueDate != nil {
+		body = fmt.Sprintf("You have been assigned \"%s\" on %s, due %s.", task.Title, project.Name, task.DueDate.Format("Jan 2, 2006"))
+	}
+	createNotifications([]models.Notification{{
+		RecipientUID: task.AssigneeUID,
+		Type:         models.NotificationTaskAssigned,
+		Title:        fmt.Sprintf("New task on %s", project.Name),
+		Body:         body,
+		PID:          project.ProjectID,
+	}})
+}
+
+// buildTaskViews adds assignee names, overdue flags and comment counts to tasks
+func buildTaskViews(tasks []models.ProjectTask) []projectTaskView {
+	views := make([]projectTaskView, 0, len(tasks))
+	if len(tasks) == 0 {
+		return views
+	}
+
+	taskIDs := make([]uint, 0, len(tasks))
+	assigneeUIDs := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+		if task.AssigneeUID != "" {
+			assigneeUIDs = append(assigneeUIDs, task.AssigneeUID)
+		}
+	}
+
+	names := make(map[string]string)
+	if len(assigneeUIDs) > 0 {
+		var assignees []models.User
+		config.DB.Select("uid, name").Where("uid IN ?", assigneeUIDs).Find(&assignees)
+		for _, assignee := range assignees {
+			names[assignee.Uid] = assignee.Name
+		}
+	}
+
+	var counts []struct {
+		TaskID uint
+		Count  int64
+	}
+	config.DB.Model(&models.TaskComment{}).Select("task_id, COUNT(*) AS count").Where("task_id IN ?", taskIDs).Group("task_id").Scan(&counts)
+	commentCounts := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		commentCounts[count.TaskID] = count.Count
+	}
+
+	today := startOfToday()
+	for _, task := range tasks {
+		views = append(views, projectTaskView{
+			ProjectTask:  task,
+			AssigneeName: names[task.AssigneeUID],
+			Overdue:      isTaskOverdue(task, today),
+			CommentCount: commentCounts[task.ID],
+		})
+	}
+	return views
+}
+
+// GetProjectMilestones lists a project's milestones, soonest due first, with the progress of their tasks
+func GetProjectMilestones(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, _, err := loadMemberProject(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you are not a member"})
+	}
+
+	milestones := []models.ProjectMilestone{}
+	if err := config.DB.Where("p_id = ?", project.ProjectID).Order("due_date ASC NULLS LAST, created_at ASC").Find(&milestones).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch milestones"})
+	}
+
+	var counts []struct {
+		MilestoneID uint
+		Total       int64
+		Done        int64
+		Overdue     int64
+	}
+	config.DB.Model(&models.ProjectTask{}).
+		Select("milestone_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE status = ?) AS done, COUNT(*) FILTER (WHERE status <> ? AND due_date < ?) AS overdue",
+			models.TaskDone, models.TaskDone, startOfToday()).
+		Where("p_id = ? AND milestone_id IS NOT NULL", project.ProjectID).
+		Group("milestone_id").
+		Scan(&counts)
+	progress := make(map[uint]int, len(counts))
+	for i, count := range counts {
+		progress[count.MilestoneID] = i
+	}
+
+	views := make([]projectMilestoneView, 0, len(milestones))
+	for _, milestone := range milestones {
+		view := projectMilestoneView{ProjectMilestone: milestone}
+		if i, ok := progress[milestone.ID]; ok {
+			view.TaskCount = counts[i].Total
+			view.DoneCount = counts[i].Done
+			view.OverdueCount = counts[i].Overdue
+		}
+		views = append(views, view)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"milestones": views,
+		"count":      len(views),
+	})
+}
+
+// CreateProjectMilestone adds a milestone to a project
+func CreateProjectMilestone(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findReviewableProject(c.Param("id"), userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		DueDate     string `json:"dueDate"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	title := strings.TrimSpace(requestBody.Title)
+	if title == "" || len([]rune(title)) > taskTitleMaxLength {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Title is required and cannot be longer than %d characters", taskTitleMaxLength)})
+	}
+	dueDate, ok := parseDueDate(requestBody.DueDate)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "dueDate must be a date in YYYY-MM-DD format"})
+	}
+
+	milestone := models.ProjectMilestone{
+		PID:         project.ProjectID,
+		Title:       title,
+		Description: strings.TrimSpace(requestBody.Description),
+		DueDate:     dueDate,
+		CreatedBy:   userData.GetUID(),
+	}
+	if err := config.DB.Create(&milestone).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to create milestone"})
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message":   "Milestone created",
+		"milestone": projectMilestoneView{ProjectMilestone: milestone},
+	})
+}
+
+// UpdateProjectMilestone changes a milestone's title, description or due date
+func UpdateProjectMilestone(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findReviewableProject(c.Param("id"), userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	milestone, err := findProjectMilestone(project.ProjectID, c.Param("milestoneId"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Milestone not found"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Title       *string `json:"title"`
+		Description *string `json:"description"`
+		DueDate     *string `json:"dueDate"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	updates := make(map[string]interface{})
+	if requestBody.Title != nil {
+		title := strings.TrimSpace(*requestBody.Title)
+		if title == "" || len([]rune(title)) > taskTitleMaxLength {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Title is required and cannot be longer than %d characters", taskTitleMaxLength)})
+		}
+		updates["title"] = title
+	}
+	if requestBody.Description != nil {
+		updates["description"] = strings.TrimSpace(*requestBody.Description)
+	}
+	if requestBody.DueDate != nil {
+		dueDate, ok := parseDueDate(*requestBody.DueDate)
+		if !ok {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "dueDate must be a date in YYYY-MM-DD format"})
+		}
+		updates["due_date"] = dueDate
+	}
+
+	if len(updates) > 0 {
+		if err := config.DB.Model(&milestone).Updates(updates).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update milestone"})
+		}
+		config.DB.First(&milestone, milestone.ID)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":   "Milestone updated",
+		"milestone": milestone,
+	})
+}
+
+// DeleteProjectMilestone deletes a milestone; its tasks stay on the project without a milestone
+func DeleteProjectMilestone(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findReviewableProject(c.Param("id"), userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	milestone, err := findProjectMilestone(project.ProjectID, c.Param("milestoneId"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Milestone not found"})
+	}
+
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Model(&models.ProjectTask{}).Where("milestone_id = ?", milestone.ID).Update("milestone_id", nil).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to detach tasks"})
+	}
+	if err := tx.Delete(&milestone).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to delete milestone"})
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "Milestone deleted"})
+}
+
+// GetProjectTasks lists a project's tasks, filtered by ?milestoneId=, ?assignee= ("me" for oneself) and ?status=
+func GetProjectTasks(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, _, err := loadMemberProject(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you are not a member"})
+	}
+
+	query := config.DB.Where("p_id = ?", project.ProjectID)
+	if milestoneID := c.QueryParam("milestoneId"); milestoneID != "" {
+		query = query.Where("milestone_id = ?", milestoneID)
+	}
+	if assignee := c.QueryParam("assignee"); assignee != "" {
+		if assignee == "me" {
+			assignee = userData.GetUID()
+		}
+		query = query.Where("assignee_uid = ?", assignee)
+	}
+	if status := c.QueryParam("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var tasks []models.ProjectTask
+	if err := query.Order("due_date ASC NULLS LAST, created_at ASC").Find(&tasks).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch tasks"})
+	}
+
+	views := buildTaskViews(tasks)
+	return c.JSON(http.StatusOK, echo.Map{
+		"tasks": views,
+		"count": len(views),
+	})
+}
+
+// CreateProjectTask adds a task to a project, optionally under a milestone and assigned to a working user
+func CreateProjectTask(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findReviewableProject(c.Param("id"), userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		MilestoneID *uint  `json:"milestoneId"`
+		AssigneeUID string `json:"assigneeUid"`
+		Status      string `json:"status"`
+		DueDate     string `json:"dueDate"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	title := strings.TrimSpace(requestBody.Title)
+	if title == "" || len([]rune(title)) > taskTitleMaxLength {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Title is required and cannot be longer than %d characters", taskTitleMaxLength)})
+	}
+	if requestBody.Status == "" {
+		requestBody.Status = models.TaskTodo
+	}
+	if !isValidTaskStatus(requestBody.Status) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid status. Must be one of: todo, in_progress, blocked, done"})
+	}
+	dueDate, ok := parseDueDate(requestBody.DueDate)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "dueDate must be a date in YYYY-MM-DD format"})
+	}
+	if requestBody.AssigneeUID != "" && !slices.Contains(project.WorkingUsers, requestBody.AssigneeUID) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Tasks can only be assigned to the project's working users"})
+	}
+	if requestBody.MilestoneID != nil {
+		if _, err := findProjectMilestone(project.ProjectID, *requestBody.MilestoneID); err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Milestone not found"})
+		}
+	}
+
+	task := models.ProjectTask{
+		PID:         project.ProjectID,
+		MilestoneID: requestBody.MilestoneID,
+		Title:       title,
+		Description: strings.TrimSpace(requestBody.Description),
+		AssigneeUID: requestBody.AssigneeUID,
+		Status:      requestBody.Status,
+		DueDate:     dueDate,
+		CreatedBy:   userData.GetUID(),
+	}
+	if task.Status == models.TaskDone {
+		now := time.Now()
+		task.CompletedAt = &now
+	}
+	if err := config.DB.Create(&task).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to create task"})
+	}
+
+	notifyTaskAssignee(project, task, userData.GetUID())
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Task created",
+		"task":    buildTaskViews([]models.ProjectTask{task})[0],
+	})
+}
+
+// UpdateProjectTask changes a task. Owners may change anything; the assignee may only move its status.
+func UpdateProjectTask(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, moderator, err := loadMemberProject(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you are not a member"})
+	}
+
+	task, err := findProjectTask(c, project.ProjectID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Task not found"})
+	}
+	if !moderator && task.AssigneeUID != userData.GetUID() {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "You can only update tasks assigned to you"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Title       *string `json:"title"`
+		Description *string `json:"description"`
+		MilestoneID *uint   `json:"milestoneId"`
+		AssigneeUID *string `json:"assigneeUid"`
+		Status      *string `json:"status"`
+		DueDate     *string `json:"dueDate"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if !moderator && (requestBody.Title != nil || requestBody.Description != nil || requestBody.MilestoneID != nil || requestBody.AssigneeUID != nil || requestBody.DueDate != nil) {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Assignees can only change a task's status"})
+	}
+
+	updates := make(map[string]interface{})
+	if requestBody.Title != nil {
+		title := strings.TrimSpace(*requestBody.Title)
+		if title == "" || len([]rune(title)) > taskTitleMaxLength {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Title is required and cannot be longer than %d characters", taskTitleMaxLength)})
+		}
+		updates["title"] = title
+	}
+	if requestBody.Description != nil {
+		updates["description"] = strings.TrimSpace(*requestBody.Description)
+	}
+	if requestBody.MilestoneID != nil {
+		// Zero moves the task out of its milestone
+		if *requestBody.MilestoneID == 0 {
+			updates["milestone_id"] = nil
+		} else if _, err := findProjectMilestone(project.ProjectID, *requestBody.MilestoneID); err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Milestone not found"})
+		} else {
+			updates["milestone_id"] = *requestBody.MilestoneID
+		}
+	}
+	if requestBody.AssigneeUID != nil {
+		if *requestBody.AssigneeUID != "" && !slices.Contains(project.WorkingUsers, *requestBody.AssigneeUID) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Tasks can only be assigned to the project's working users"})
+		}
+		updates["assignee_uid"] = *requestBody.AssigneeUID
+	}
+	if requestBody.Status != nil && *requestBody.Status != task.Status {
+		if !isValidTaskStatus(*requestBody.Status) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid status. Must be one of: todo, in_progress, blocked, done"})
+		}
+		for column, value := range taskStatusUpdates(*requestBody.Status) {
+			updates[column] = value
+		}
+	}
+	if requestBody.DueDate != nil {
+		dueDate, ok := parseDueDate(*requestBody.DueDate)
+		if !ok {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "dueDate must be a date in YYYY-MM-DD format"})
+		}
+		updates["due_date"] = dueDate
+	}
+
+	previousAssignee := task.AssigneeUID
+	if len(updates) > 0 {
+		if err := config.DB.Model(&task).Updates(updates).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update task"})
+		}
+		config.DB.First(&task, task.ID)
+	}
+
+	if task.AssigneeUID != previousAssignee {
+		notifyTaskAssignee(project, task, userData.GetUID())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Task updated",
+		"task":    buildTaskViews([]models.ProjectTask{task})[0],
+	})
+}
+
+// DeleteProjectTask deletes a task and its comments
+func DeleteProjectTask(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findReviewableProject(c.Param("id"), userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	task, err := findProjectTask(c, project.ProjectID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Task not found"})
+	}
+
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Where("task_id = ?", task.ID).Delete(&models.TaskComment{}).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to delete task comments"})
+	}
+	if err := tx.Delete(&task).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to delete task"})
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "Task deleted"})
+}
+
+// GetTaskComments returns a task's comments, oldest first, with their authors' names
+func GetTaskComments(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, _, err := loadMemberProject(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you are not a member"})
+	}
+
+	task, err := findProjectTask(c, project.ProjectID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Task not found"})
+	}
+
+	comments := []models.TaskComment{}
+	if err := config.DB.Where("task_id = ?", task.ID).Order("created_at ASC").Find(&comments).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch comments"})
+	}
+
+	authorUIDs := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		authorUIDs = append(authorUIDs, comment.AuthorUID)
+	}
+	participants := loadParticipants(authorUIDs)
+
+	results := make([]echo.Map, 0, len(comments))
+	for _, comment := range comments {
+		results = append(results, echo.Map{
+			"id":        comment.ID,
+			"taskId":    comment.TaskID,
+			"author":    participants[comment.AuthorUID],
+			"body":      comment.Body,
+			"createdAt": comment.CreatedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"taskId":   task.ID,
+		"comments": results,
+		"count":    len(results),
+	})
+}
+
+// AddTaskComment lets a project member comment on a task; the assignee and the task's creator are notified
+func AddTaskComment(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, _, err := loadMemberProject(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you are not a member"})
+	}
+
+	task, err := findProjectTask(c, project.ProjectID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Task not found"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Body string `json:"body"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	body := strings.TrimSpace(requestBody.Body)
+	if body == "" || len([]rune(body)) > taskCommentMaxLength {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Comment is required and cannot be longer than %d characters", taskCommentMaxLength)})
+	}
+
+	comment := models.TaskComment{TaskID: task.ID, AuthorUID: userData.GetUID(), Body: body}
+	if err := config.DB.Create(&comment).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to add comment"})
+	}
+
+	var notifications []models.Notification
+	notified := []string{"", userData.GetUID()}
+	for _, uid := range []string{task.AssigneeUID, task.CreatedBy} {
+		if slices.Contains(notified, uid) {
+			continue
+		}
+		notified = append(notified, uid)
+		notifications = append(notifications, models.Notification{
+			RecipientUID: uid,
+			Type:         models.NotificationTaskComment,
+			Title:        fmt.Sprintf("New comment on \"%s\"", task.Title),
+			Body:         body,
+			PID:          project.ProjectID,
+		})
+	}
+	createNotifications(notifications)
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Comment added",
+		"comment": echo.Map{
+			"id":        comment.ID,
+			"taskId":    comment.TaskID,
+			"author":    loadParticipants([]string{comment.AuthorUID})[comment.AuthorUID],
+			"body":      comment.Body,
+			"createdAt": comment.CreatedAt,
+		},
+	})
+}
+
+// GetProjectProgress shows each working user's task progress, members who are behind first.
+// Owners see every working user; a working user sees only their own row.
+func GetProjectProgress(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, moderator, err := loadMemberProject(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you are not a member"})
+	}
+
+	members := []string(project.WorkingUsers)
+	if !moderator {
+		members = []string{userData.GetUID()}
+	}
+
+	var counts []struct {
+		AssigneeUID    string
+		Assigned       int64
+		Done           int64
+		InProgress     int64
+		Blocked        int64
+		Overdue        int64
+		LastActivityAt *time.Time
+	}
+	if len(members) > 0 {
+		if err := config.DB.Model(&models.ProjectTask{}).
+			Select("assignee_uid, COUNT(*) AS assigned, "+
+				"COUNT(*) FILTER (WHERE status = ?) AS done, "+
+				"COUNT(*) FILTER (WHERE status = ?) AS in_progress, "+
+				"COUNT(*) FILTER (WHERE status = ?) AS blocked, "+
+				"COUNT(*) FILTER (WHERE status <> ? AND due_date < ?) AS overdue, "+
+				"MAX(updated_at) AS last_activity_at",
+				models.TaskDone, models.TaskInProgress, models.TaskBlocked, models.TaskDone, startOfToday()).
+			Where("p_id = ? AND assignee_uid IN ?", project.ProjectID, members).
+			Group("assignee_uid").
+			Scan(&counts).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to compute progress"})
+		}
+	}
+
+	progressByUID := make(map[string]memberProgress, len(members))
+	for _, count := range counts {
+		progress := memberProgress{
+			UID:            count.AssigneeUID,
+			Assigned:       count.Assigned,
+			Done:           count.Done,
+			InProgress:     count.InProgress,
+			Blocked:        count.Blocked,
+			Overdue:        count.Overdue,
+			LastActivityAt: count.LastActivityAt,
+			Behind:         count.Overdue > 0,
+		}
+		if count.Assigned > 0 {
+			progress.CompletionRate = float64(count.Done) * 100 / float64(count.Assigned)
+		}
+		progressByUID[count.AssigneeUID] = progress
+	}
+
+	participants := loadParticipants(members)
+	results := make([]memberProgress, 0, len(members))
+	for _, uid := range members {
+		progress, ok := progressByUID[uid]
+		if !ok {
+			progress = memberProgress{UID: uid}
+		}
+		if participant, ok := participants[uid]; ok {
+			progress.Name, _ = participant["name"].(string)
+		}
+		results = append(results, progress)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Overdue != results[j].Overdue {
+			return results[i].Overdue > results[j].Overdue
+		}
+		return results[i].Name < results[j].Name
+	})
+
+	behind := 0
+	for _, progress := range results {
+		if progress.Behind {
+			behind++
+		}
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"members": results,
+		"count":   len(results),
+		"behind":  behind,
+	})
+}
@@ -0,0 +1,709 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxRecommendationsPerApplication = 3
+	maxRecommendationLetterLength    = 20000
+	maxManualRecommendationReminders = 2              // Reminders a student may send on top of the automatic ones
+	autoRecommendationReminders      = 3              // Automatic reminders sent before giving up
+	recommendationReminderInterval   = 72 * time.Hour // Gap between automatic reminders
+	recommendationManualReminderGap  = 24 * time.Hour // Minimum gap before a student may nudge again
+	recommendationReminderTick       = 1 * time.Hour  // How often the reminder job runs
+	recommendationTokenLifetime      = 30 * 24 * time.Hour
+)
+
+// recommendationStatus is the student-facing view of a recommendation request; it never includes the letter
+type recommendationStatus struct {
+	ID                  uint       `json:"id"`
+	RefereeUID          string     `json:"refereeUid"`
+	RefereeName         string     `json:"refereeName"`
+	Status              string     `json:"status"`
+	Note                string     `json:"note"`
+	RequestedAt         time.Time  `json:"requestedAt"`
+	RespondedAt         *time.Time `json:"respondedAt"`
+	ReminderCount       int        `json:"reminderCount"`
+	ManualReminderCount int        `json:"manualReminderCount"`
+	LastRemindedAt      *time.Time `json:"lastRemindedAt"`
+}
+
+// recommendationUploadURL builds the referee's personal letter upload link
+func recommendationUploadURL(token string) string {
+	return fmt.Sprintf("%s/recommendations/%s", os.Getenv("FRONTEND_URL"), token)
+}
+
+// loadRecommendationStatuses loads the non-cancelled recommendation requests of many applications, keyed by application ID
+func loadRecommendationStatuses(applicationIDs []uint) map[uint][]recommendationStatus {
+	statuses := make(map[uint][]recommendationStatus)
+	if len(applicationIDs) == 0 {
+		return statuses
+	}
+
+	var requests []models.RecommendationRequest
+	config.DB.Where("application_id IN ? AND status != ?", applicationIDs, models.RecommendationCancelled).
+		Order("created_at ASC").Find(&requests)
+
+	refereeUIDs := make([]string, 0, len(requests))
+	for _, request := range requests {
+		refereeUIDs = append(refereeUIDs, request.RefereeUID)
+	}
+	names := make(map[string]string, len(refereeUIDs))
+	if len(refereeUIDs) > 0 {
+		var referees []models.User
+		config.DB.Select("uid, name").Where("uid IN ?", refereeUIDs).Find(&referees)
+		for _, referee := range referees {
+			names[referee.Uid] = referee.Name
+		}
+	}
+
+	for _, request := range requests {
+		statuses[request.ApplicationID] = append(statuses[request.ApplicationID], recommendationStatus{
+			ID:                  request.ID,
+			RefereeUID:          request.RefereeUID,
+			RefereeName:         names[request.RefereeUID],
+			Status:              request.Status,
+			Note:                request.Note,
+			RequestedAt:         request.CreatedAt,
+			RespondedAt:         request.RespondedAt,
+			ReminderCount:       request.ReminderCount,
+			ManualReminderCount: request.ManualReminderCount,
+			LastRemindedAt:      request.LastRemindedAt,
+		})
+	}
+	return statuses
+}
+
+// sendRecommendationRequestEmail emails the referee their upload link, either as the first request or as a reminder
+func sendRecommendationRequestEmail(request models.RecommendationRequest, reminder bool) error {
+	var referee, student models.User
+	if err := config.DB.Where("uid = ?", request.RefereeUID).First(&referee).Error; err != nil {
+		return fmt.Errorf("failed to fetch referee: %w", err)
+	}
+	if err := config.DB.Where("uid = ?", request.StudentUID).First(&student).Error; err != nil {
+		return fmt.Errorf("failed to fetch student: %w", err)
+	}
+
+	var project models.Projects
+	if err := config.DB.Unscoped().Where("project_id = ?", request.PID).First(&project).Error; err != nil {
+		return fmt.Errorf("failed to fetch project: %w", err)
+	}
+
+	message, err := buildRecommendationRequestEmail(referee.Email, loadEmailLocale(referee.Uid), referee.Name, student.Name, project.Name, request.Note, recommendationUploadURL(request.Token), reminder)
+	if err != nil {
+		return err
+	}
+	return utils.SendEmail(utils.LoadEmailConfig(), message)
+}
+
+// recordRecommendationReminder bumps the automatic or manual reminder counter after a reminder was sent
+// and keeps the upload link it carries valid
+func recordRecommendationReminder(request *models.RecommendationRequest, now time.Time, manual bool) error {
+	expiresAt := now.Add(recommendationTokenLifetime)
+	request.LastRemindedAt = &now
+	request.TokenExpiresAt = &expiresAt
+	updates := map[string]interface{}{
+		"last_reminded_at": now,
+		"token_expires_at": expiresAt,
+	}
+	if manual {
+		request.ManualReminderCount++
+		updates["manual_reminder_count"] = request.ManualReminderCount
+	} else {
+		request.ReminderCount++
+		updates["reminder_count"] = request.ReminderCount
+	}
+	return config.DB.Model(request).Updates(updates).Error
+}
+
+// lastRecommendationContact returns when the referee was last emailed about a request
+func lastRecommendationContact(request models.RecommendationRequest) time.Time {
+	if request.LastRemindedAt != nil {
+		return *request.LastRemindedAt
+	}
+	return request.CreatedAt
+}
+
+// remindPendingRecommendations sends automatic reminders for requests referees have not answered yet
+func remindPendingRecommendations() {
+	now := time.Now()
+
+	var requests []models.RecommendationRequest
+	if err := config.DB.Where("status = ? AND reminder_count < ? AND COALESCE(last_reminded_at, created_at) < ?",
+		models.RecommendationPending, autoRecommendationReminders, now.Add(-recommendationReminderInterval)).
+		Find(&requests).Error; err != nil {
+		log.Printf("Failed to load pending recommendation requests: %v", err)
+		return
+	}
+
+	for i := range requests {
+		// Stop chasing once the application has been decided
+		var application models.ProjRequests
+		if err := config.DB.Select("id, status").Where("id = ?", requests[i].ApplicationID).First(&application).Error; err != nil || isApplicationDecided(application.Status) {
+			continue
+		}
+
+		if err := sendRecommendationRequestEmail(requests[i], true); err != nil {
+			log.Printf("Failed to send recommendation reminder %d: %v", requests[i].ID, err)
+			continue
+		}
+		if err := recordRecommendationReminder(&requests[i], now, false); err != nil {
+			log.Printf("Failed to record recommendation reminder %d: %v", requests[i].ID, err)
+		}
+	}
+}
+
+// StartRecommendationReminders starts a goroutine that periodically reminds referees of pending requests
+func StartRecommendationReminders() {
+	ticker := time.NewTicker(recommendationReminderTick)
+	go func() {
+		for range ticker.C {
+			remindPendingRecommendations()
+		}
+	}()
+}
+
+// RequestRecommendation lets a student ask a faculty member for a letter supporting one of their applications
+func RequestRecommendation(c echo.Context) error {
+	applicationID := c.Param("appId")
+	if applicationID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Application ID is required"})
+	}
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can request recommendations"})
+	}
+
+	// Parse request body; the referee can be identified by UID or email
+	var requestBody struct {
+		RefereeUID   string `json:"refereeUid"`
+		RefereeEmail string `json:"refereeEmail"`
+		Note         string `json:"note"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if requestBody.RefereeUID == "" && requestBody.RefereeEmail == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "refereeUid or refereeEmail is required"})
+	}
+
+	var application models.ProjRequests
+	if err := config.DB.Where("id = ? AND uid = ?", applicationID, userData.GetUID()).First(&application).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Application not found"})
+	}
+
+	if isApplicationDecided(application.Status) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "This application has already been decided"})
+	}
+
+	var referee models.User
+	query := config.DB.Where("type = ?", models.UserTypeFaculty)
+	if requestBody.RefereeUID != "" {
+		query = query.Where("uid = ?", requestBody.RefereeUID)
+	} else {
+		query = query.Where("LOWER(email) = ?", strings.ToLower(strings.TrimSpace(requestBody.RefereeEmail)))
+	}
+	if err := query.First(&referee).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Referee not found; recommendations can only be requested from faculty members"})
+	}
+
+	token, err := utils.GenerateRecommendationToken()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to generate upload link"})
+	}
+
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Lock the application so concurrent requests cannot both pass the referee limit
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", application.ID).First(&application).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to request recommendation"})
+	}
+
+	tokenExpiresAt := time.Now().Add(recommendationTokenLifetime)
+	var active int64
+	tx.Model(&models.RecommendationRequest{}).
+		Where("application_id = ? AND status IN ?", application.ID, []string{models.RecommendationPending, models.RecommendationSubmitted}).
+		Count(&active)
+	if active >= maxRecommendationsPerApplication {
+		tx.Rollback()
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("You can request at most %d recommendations per application", maxRecommendationsPerApplication)})
+	}
+
+	// A declined or cancelled request to the same referee is reopened instead of duplicated
+	var request models.RecommendationRequest
+	err = tx.Where("application_id = ? AND referee_uid = ?", application.ID, referee.Uid).First(&request).Error
+	switch {
+	case err == nil && (request.Status == models.RecommendationPending || request.Status == models.RecommendationSubmitted):
+		tx.Rollback()
+		return c.JSON(http.StatusConflict, echo.Map{"error": "You have already asked this faculty member for a recommendation"})
+	case err == nil:
+		request.Status = models.RecommendationPending
+		request.Note = requestBody.Note
+		request.Token = token
+		request.TokenExpiresAt = &tokenExpiresAt
+		request.Letter = ""
+		request.LetterLink = ""
+		request.RespondedAt = nil
+		request.ReminderCount = 0
+		request.ManualReminderCount = 0
+		request.LastRemindedAt = nil
+		request.CreatedAt = time.Now()
+		if err := tx.Save(&request).Error; err != nil {
+			tx.Rollback()
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to request recommendation"})
+		}
+	default:
+		request = models.RecommendationRequest{
+			ApplicationID:  application.ID,
+			PID:            application.PID,
+			StudentUID:     userData.GetUID(),
+			RefereeUID:     referee.Uid,
+			Note:           requestBody.Note,
+			Status:         models.RecommendationPending,
+			Token:          token,
+			TokenExpiresAt: &tokenExpiresAt,
+		}
+		if err := tx.Create(&request).Error; err != nil {
+			tx.Rollback()
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to request recommendation"})
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save recommendation request"})
+	}
+
+	// Email the referee their upload link
+	go func() {
+		if err := sendRecommendationRequestEmail(request, false); err != nil {
+			log.Printf("Failed to send recommendation request %d: %v", request.ID, err)
+		}
+	}()
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message":         "Recommendation requested successfully",
+		"recommendations": loadRecommendationStatuses([]uint{application.ID})[application.ID],
+	})
+}
+
+// GetMyRecommendationRequests lists the recommendation requests of one of the student's applications
+func GetMyRecommendationRequests(c echo.Context) error {
+	applicationID := c.Param("appId")
+
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can view their recommendation requests"})
+	}
+
+	var application models.ProjRequests
+	if err := config.DB.Where("id = ? AND uid = ?", applicationID, userData.GetUID()).First(&application).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Application not found"})
+	}
+
+	recommendations := loadRecommendationStatuses([]uint{application.ID})[application.ID]
+	if recommendations == nil {
+		recommendations = []recommendationStatus{}
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"applicationId":   application.ID,
+		"recommendations": recommendations,
+		"count":           len(recommendations),
+	})
+}
+
+// findMyRecommendationRequest loads a recommendation request belonging to one of the student's applications
+func findMyRecommendationRequest(c echo.Context, uid string) (models.RecommendationRequest, error) {
+	var request models.RecommendationRequest
+	err := config.DB.Where("id = ? AND application_id = ? AND student_uid = ?", c.Param("recId"), c.Param("appId"), uid).First(&request).Error
+	return request, err
+}
+
+// RemindRecommendation lets a student nudge a referee who has not answered yet
+func RemindRecommendation(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can send recommendation reminders"})
+	}
+
+	request, err := findMyRecommendationRequest(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Recommendation request not found"})
+	}
+
+	if request.Status != models.RecommendationPending {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Only pending requests can be reminded"})
+	}
+
+	if request.ManualReminderCount >= maxManualRecommendationReminders {
+		return c.JSON(http.StatusTooManyRequests, echo.Map{"error": "This referee has already been reminded several times"})
+	}
+
+	now := time.Now()
+	if next := lastRecommendationContact(request).Add(recommendationManualReminderGap); now.Before(next) {
+		return c.JSON(http.StatusTooManyRequests, echo.Map{
+			"error":          "The referee was contacted recently; please wait before sending another reminder",
+			"nextReminderAt": next,
+		})
+	}
+
+	if err := recordRecommendationReminder(&request, now, true); err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to record reminder"})
+	}
+
+	go func() {
+		if err := sendRecommendationRequestEmail(request, true); err != nil {
+			log.Printf("Failed to send recommendation reminder %d: %v", request.ID, err)
+		}
+	}()
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":             "Reminder sent successfully",
+		"reminderCount":       request.ReminderCount,
+		"manualReminderCount": request.ManualReminderCount,
+	})
+}
+
+// CancelRecommendation withdraws a pending recommendation request
+func CancelRecommendation(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a student
+	if userData.GetUserType() != models.UserTypeStudent {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only students can cancel recommendation requests"})
+	}
+
+	request, err := findMyRecommendationRequest(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Recommendation request not found"})
+	}
+
+	if request.Status != models.RecommendationPending {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Only pending requests can be cancelled"})
+	}
+
+	if err := config.DB.Model(&request).Update("status", models.RecommendationCancelled).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to cancel recommendation request"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "Recommendation request cancelled"})
+}
+
+// findRecommendationByToken loads the request behind a referee's upload link, failing once the link has expired
+func findRecommendationByToken(token string) (models.RecommendationRequest, error) {
+	var request models.RecommendationRequest
+	if token == "" {
+		return request, fmt.Errorf("token is required")
+	}
+	if err := config.DB.Where("token = ?", token).First(&request).Error; err != nil {
+		return request, err
+	}
+
+	// Requests from before links expired are measured from the last email the referee got
+	expiresAt := lastRecommendationContact(request).Add(recommendationTokenLifetime)
+	if request.TokenExpiresAt != nil {
+		expiresAt = *request.TokenExpiresAt
+	}
+	if time.Now().After(expiresAt) {
+		return request, fmt.Errorf("token has expired")
+	}
+	return request, nil
+}
+
+// GetRecommendationLetterRequest shows the referee what they are being asked to write; the token in the URL authenticates
+func GetRecommendationLetterRequest(c echo.Context) error {
+	request, err := findRecommendationByToken(c.Param("token"))
+	if err != nil || request.Status == models.RecommendationCancelled {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Recommendation request not found or no longer active"})
+	}
+
+	var student, referee models.User
+	config.DB.Where("uid = ?", request.StudentUID).First(&student)
+	config.DB.Where("uid = ?", request.RefereeUID).First(&referee)
+
+	var project models.Projects
+	config.DB.Unscoped().Where("project_id = ?", request.PID).First(&project)
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"id":          request.ID,
+		"status":      request.Status,
+		"note":        request.Note,
+		"requestedAt": request.CreatedAt,
+		"respondedAt": request.RespondedAt,
+		"studentName": student.Name,
+		"refereeName": referee.Name,
+		"projectId":   project.ProjectID,
+		"projectName": project.Name,
+		"letter":      request.Letter, // The referee may read back their own letter
+		"letterLink":  request.LetterLink,
+	})
+}
+
+// respondToRecommendation stores the referee's answer and tells the student
+func respondToRecommendation(request models.RecommendationRequest, updates map[string]interface{}) error {
+	// Only update while still pending so a double submit cannot overwrite a letter
+	result := config.DB.Model(&models.RecommendationRequest{}).
+		Where("id = ? AND status = ?", request.ID, models.RecommendationPending).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("request is no longer pending")
+	}
+
+	go func() {
+		var student, referee models.User
+		if err := config.DB.Where("uid = ?", request.StudentUID).First(&student).Error; err != nil {
+			log.Printf("Failed to fetch student for recommendation email: %v", err)
+			return
+		}
+		config.DB.Where("uid = ?", request.RefereeUID).First(&referee)
+
+		var project models.Projects
+		config.DB.Unscoped().Where("project_id = ?", request.PID).First(&project)
+
+		message, err := buildRecommendationResponseEmail(student.Email, loadEmailLocale(student.Uid), student.Name, referee.Name, project.Name, updates["status"].(string))
+		if err != nil {
+			log.Printf("Failed to build recommendation update to student %s: %v", student.Email, err)
+			return
+		}
+		if err := utils.SendEmail(utils.LoadEmailConfig(), message); err != nil {
+			log.Printf("Failed to send recommendation update to student %s: %v", student.Email, err)
+		}
+	}()
+
+	return nil
+}
+
+// SubmitRecommendationLetter stores the referee's letter; the token in the URL authenticates
+func SubmitRecommendationLetter(c echo.Context) error {
+	request, err := findRecommendationByToken(c.Param("token"))
+	if err != nil || request.Status == models.RecommendationCancelled {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Recommendation request not found or no longer active"})
+	}
+
+	if request.Status != models.RecommendationPending {
+		return c.JSON(http.StatusConflict, echo.Map{"error": "This recommendation request has already been answered"})
+	}
+
+	// Parse request body; a written letter, a document link or both
+	var requestBody struct {
+		Letter     string `json:"letter"`
+		LetterLink string `json:"letterLink"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	letter := strings.TrimSpace(requestBody.Letter)
+	letterLink := strings.TrimSpace(requestBody.LetterLink)
+	if letter == "" && letterLink == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "A letter or a link to the letter is required"})
+	}
+	if len(letter) > maxRecommendationLetterLength {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Letter must be at most %d characters", maxRecommendationLetterLength)})
+	}
+	if letterLink != "" {
+		if parsed, err := url.Parse(letterLink); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Letter link must be an http(s) URL"})
+		}
+	}
+
+	now := time.Now()
+	if err := respondToRecommendation(request, map[string]interface{}{
+		"status":       models.RecommendationSubmitted,
+		"letter":       letter,
+		"letter_link":  letterLink,
+		"responded_at": now,
+	}); err != nil {
+		return c.JSON(http.StatusConflict, echo.Map{"error": "This recommendation request has already been answered"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "Recommendation letter submitted successfully"})
+}
+
+// DeclineRecommendationRequest lets the referee turn a request down; the token in the URL authenticates
+func DeclineRecommendationRequest(c echo.Context) error {
+	request, err := findRecommendationByToken(c.Param("token"))
+	if err != nil || request.Status == models.RecommendationCancelled {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Recommendation request not found or no longer active"})
+	}
+
+	if request.Status != models.RecommendationPending {
+		return c.JSON(http.StatusConflict, echo.Map{"error": "This recommendation request has already been answered"})
+	}
+
+	if err := respondToRecommendation(request, map[string]interface{}{
+		"status":       models.RecommendationDeclined,
+		"responded_at": time.Now(),
+	}); err != nil {
+		return c.JSON(http.StatusConflict, echo.Map{"error": "This recommendation request has already been answered"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "Recommendation request declined"})
+}
+
+// GetMyRefereeRequests lists the recommendation requests addressed to the authenticated faculty member
+func GetMyRefereeRequests(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Verify user is a faculty member
+	if userData.GetUserType() != models.UserTypeFaculty {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Only faculty can receive recommendation requests"})
+	}
+
+	type RefereeRequestResponse struct {
+		ID          uint       `json:"id"`
+		Status      string     `json:"status"`
+		Note        string     `json:"note"`
+		RequestedAt time.Time  `json:"requestedAt"`
+		RespondedAt *time.Time `json:"respondedAt"`
+		StudentUID  string     `json:"studentUid"`
+		StudentName string     `json:"studentName"`
+		ProjectID   string     `json:"projectId"`
+		ProjectName string     `json:"projectName"`
+		UploadToken string     `json:"uploadToken"` // Lets the referee open the upload page from the dashboard
+	}
+
+	var requests []models.RecommendationRequest
+	if err := config.DB.Where("referee_uid = ? AND status != ?", userData.GetUID(), models.RecommendationCancelled).
+		Order("created_at DESC").Find(&requests).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch recommendation requests"})
+	}
+
+	response := make([]RefereeRequestResponse, 0, len(requests))
+	for _, request := range requests {
+		var student models.User
+		config.DB.Select("uid, name").Where("uid = ?", request.StudentUID).First(&student)
+
+		var project models.Projects
+		config.DB.Unscoped().Select("project_id, name").Where("project_id = ?", request.PID).First(&project)
+
+		response = append(response, RefereeRequestResponse{
+			ID:          request.ID,
+			Status:      request.Status,
+			Note:        request.Note,
+			RequestedAt: request.CreatedAt,
+			RespondedAt: request.RespondedAt,
+			StudentUID:  request.StudentUID,
+			StudentName: student.Name,
+			ProjectID:   request.PID,
+			ProjectName: project.Name,
+			UploadToken: request.Token,
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"requests": response,
+		"count":    len(response),
+	})
+}
+
+// GetApplicationRecommendations returns the submitted letters of an application to the project's owners and reviewers
+func GetApplicationRecommendations(c echo.Context) error {
+	application, err := findReviewableApplication(c)
+	if err != nil {
+		return reviewError(c, err)
+	}
+
+	var project models.Projects
+	if err := config.DB.Where("project_id = ?", application.PID).First(&project).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	}
+
+	var requests []models.RecommendationRequest
+	if err := config.DB.Where("application_id = ? AND status IN ?", application.ID, []string{models.RecommendationPending, models.RecommendationSubmitted}).
+		Order("responded_at ASC").Find(&requests).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch recommendations"})
+	}
+
+	pending := 0
+	submitted := make([]models.RecommendationRequest, 0, len(requests))
+	for _, request := range requests {
+		if request.Status == models.RecommendationPending {
+			pending++
+		} else {
+			submitted = append(submitted, request)
+		}
+	}
+
+	// Letters name the applicant, so they stay sealed while the project is in blind review
+	if isApplicantHidden(project, application.Status) {
+		return c.JSON(http.StatusOK, echo.Map{
+			"applicationId": application.ID,
+			"letters":       []interface{}{},
+			"submitted":     len(submitted),
+			"pending":       pending,
+			"hidden":        true,
+		})
+	}
+
+	type LetterResponse struct {
+		ID           uint       `json:"id"`
+		RefereeUID   string     `json:"refereeUid"`
+		RefereeName  string     `json:"refereeName"`
+		RefereeEmail string     `json:"refereeEmail"`
+		Letter       string     `json:"letter"`
+		LetterLink   string     `json:"letterLink"`
+		SubmittedAt  *time.Time `json:"submittedAt"`
+	}
+
+	letters := make([]LetterResponse, 0, len(submitted))
+	for _, request := range submitted {
+		var referee models.User
+		config.DB.Where("uid = ?", request.RefereeUID).First(&referee)
+
+		letters = append(letters, LetterResponse{
+			ID:           request.ID,
+			RefereeUID:   request.RefereeUID,
+			RefereeName:  referee.Name,
+			RefereeEmail: referee.Email,
+			Letter:       request.Letter,
+			LetterLink:   request.LetterLink,
+			SubmittedAt:  request.RespondedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"applicationId": application.ID,
+		"letters":       letters,
+		"submitted":     len(submitted),
+		"pending":       pending,
+		"hidden":        false,
+	})
+}
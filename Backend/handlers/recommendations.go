@@ -105,6 +105,71 @@ type RecommendedProject struct {
 	MatchReasons []string    `json:"match_reasons"`
 }
 
+// studentMatchContext holds what scoring projects for a student needs, loaded once per student
+type studentMatchContext struct {
+	student               models.Students
+	preferences           models.ResearchPreference
+	hasPreferences        bool
+	appliedProjects       map[string]bool   // Every project the student applied to, in any status
+	recentAppliedProjects []models.Projects // Projects applied to in the past 3 months, for similarity matching
+	profile               eligibilityProfile
+}
+
+// loadStudentMatchContext loads a student's profile, preferences and application history
+func loadStudentMatchContext(uid string) (*studentMatchContext, error) {
+	match := &studentMatchContext{}
+	if err := config.DB.Where("uid = ?", uid).First(&match.student).Error; err != nil {
+		return nil, err
+	}
+
+	// Get research preferences if they exist
+	match.hasPreferences = config.DB.Where("user_id = ?", uid).First(&match.preferences).Error == nil
+
+	// Batch query: Get ALL of student's applications (all statuses) to filter out
+	var applications []models.ProjRequests
+	config.DB.Select("p_id").Where("uid = ?", uid).Find(&applications)
+	match.appliedProjects = make(map[string]bool, len(applications))
+	for _, app := range applications {
+		match.appliedProjects[app.PID] = true
+	}
+
+	// Batch query: Get recent applications (past 3 months) for similarity matching
+	threeMonthsAgo := time.Now().AddDate(0, -3, 0)
+	var recentApplications []models.ProjRequests
+	config.DB.Where("uid = ? AND time_created >= ?", uid, threeMonthsAgo).Find(&recentApplications)
+
+	// Batch query: Fetch the actual projects the student applied to recently
+	recentPIDs := make([]string, 0, len(recentApplications))
+	for _, app := range recentApplications {
+		recentPIDs = append(recentPIDs, app.PID)
+	}
+	if len(recentPIDs) > 0 {
+		config.DB.Where("project_id IN ?", recentPIDs).Find(&match.recentAppliedProjects)
+	}
+
+	match.profile = eligibilityProfile{Student: match.student, HasProfile: true}
+	if match.hasPreferences {
+		match.profile.CurrentYear = match.preferences.CurrentYear
+	}
+	return match, nil
+}
+
+// isCandidate reports whether a project can be recommended: not applied to, still open and within the eligibility rules
+func (m *studentMatchContext) isCandidate(project models.Projects, now time.Time) bool {
+	// Skip if already applied
+	if m.appliedProjects[project.ProjectID] {
+		return false
+	}
+
+	// Skip projects with past deadlines
+	if project.Deadline != nil && *project.Deadline != "" && isDeadlinePassed(*project.Deadline, now) {
+		return false
+	}
+
+	// Skip projects whose eligibility rules the student does not meet
+	return len(evaluateEligibility(project, m.profile)) == 0
+}
+
 // GetRecommendedProjects returns personalized project recommendations for a student
 func GetRecommendedProjects(c echo.Context) error {
 	// Get authenticated user from context
@@ -133,18 +198,14 @@ func GetRecommendedProjects(c echo.Context) error {
 		})
 	}
 
-	// Get student profile
-	var student models.Students
-	if err := config.DB.Where("uid = ?", userData.UID).First(&student).Error; err != nil {
+	// Get student profile and match inputs
+	match, err := loadStudentMatchContext(userData.UID)
+	if err != nil {
 		return c.JSON(http.StatusNotFound, echo.Map{
 			"error": "Student profile not found. Please complete your profile first.",
 		})
 	}
 
-	// Get research preferences if they exist
-	var preferences models.ResearchPreference
-	hasPreferences := config.DB.Where("user_id = ?", userData.UID).First(&preferences).Error == nil
-
 	// Batch query: Get all active projects that are NOT created by the student
 	var projects []models.Projects
 	if err := config.DB.Where("is_active = ? AND creator_id != ?", true, userData.UID).Find(&projects).Error; err != nil {
@@ -153,47 +214,16 @@ func GetRecommendedProjects(c echo.Context) error {
 		})
 	}
 
-	// Batch query: Get ALL of student's applications (all statuses) to filter out
-	var applications []models.ProjRequests
-	config.DB.Select("p_id").Where("uid = ?", userData.UID).Find(&applications)
-	appliedProjects := make(map[string]bool, len(applications))
-	for _, app := range applications {
-		appliedProjects[app.PID] = true
-	}
-
-	// Batch query: Get recent applications (past 3 months) for similarity matching
-	threeMonthsAgo := time.Now().AddDate(0, -3, 0)
-	var recentApplications []models.ProjRequests
-	config.DB.Where("uid = ? AND time_created >= ?", userData.UID, threeMonthsAgo).Find(&recentApplications)
-
-	// Batch query: Fetch the actual projects the student applied to recently
-	var recentAppliedProjects []models.Projects
-	recentPIDs := make([]string, 0, len(recentApplications))
-	for _, app := range recentApplications {
-		recentPIDs = append(recentPIDs, app.PID)
-	}
-	if len(recentPIDs) > 0 {
-		config.DB.Where("project_id IN ?", recentPIDs).Find(&recentAppliedProjects)
-	}
-
 	// Pre-filter projects and collect creator IDs
 	currentTime := time.Now()
 	var eligibleProjects []models.Projects
 	creatorIDs := make(map[string]bool)
 
 	for _, project := range projects {
-		// Skip if already applied
-		if appliedProjects[project.ProjectID] {
+		if !match.isCandidate(project, currentTime) {
 			continue
 		}
 
-		// Skip projects with past deadlines
-		if project.Deadline != nil && *project.Deadline != "" {
-			if isDeadlinePassed(*project.Deadline, currentTime) {
-				continue
-			}
-		}
-
 		eligibleProjects = append(eligibleProjects, project)
 		creatorIDs[project.CreatorID] = true
 	}
@@ -228,7 +258,7 @@ func GetRecommendedProjects(c echo.Context) error {
 			scoringSemaphore <- struct{}{}
 			defer func() { <-scoringSemaphore }()
 
-			matchScore, reasons := calculateMatchScore(student, proj, hasPreferences, preferences, recentAppliedProjects)
+			matchScore, reasons := calculateMatchScore(match.student, proj, match.hasPreferences, match.preferences, match.recentAppliedProjects)
 
 			// Only include projects with a minimum match score
 			if matchScore >= 20.0 {
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"strings"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// normalizeReviewers trims and de-duplicates reviewer UIDs, dropping the project creator and
+// reporting false if any of them is not a faculty account
+func normalizeReviewers(db *gorm.DB, creatorUID string, uids []string) (pq.StringArray, bool) {
+	seen := make(map[string]bool, len(uids))
+	reviewers := pq.StringArray{}
+	for _, uid := range uids {
+		uid = strings.TrimSpace(uid)
+		if uid == "" || uid == creatorUID || seen[uid] {
+			continue
+		}
+		seen[uid] = true
+		reviewers = append(reviewers, uid)
+	}
+
+	if len(reviewers) == 0 {
+		return reviewers, true
+	}
+
+	var count int64
+	db.Model(&models.User{}).Where("uid IN ? AND type = ?", []string(reviewers), models.UserTypeFaculty).Count(&count)
+	return reviewers, count == int64(len(reviewers))
+}
+
+// findReviewableProject loads a project the user owns or has been added to as a reviewer
+func findReviewableProject(projectID, uid string) (models.Projects, error) {
+	var project models.Projects
+	err := config.DB.Where("project_id = ? AND (creator_id = ? OR ? = ANY(reviewers))", projectID, uid, uid).First(&project).Error
+	return project, err
+}
@@ -228,6 +228,7 @@ func GenerateRoadmap(c echo.Context) error {
 			GeneratedBy:    "gemini-dedup-cached",
 		}
 		config.DB.Create(&roadmap)
+		publishRoadmapReady(userID, roadmap)
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
 			"message":   "Roadmap generated successfully",
@@ -273,6 +274,7 @@ func GenerateRoadmap(c echo.Context) error {
 		GeneratedBy:    "gemini",
 	}
 	config.DB.Create(&roadmap)
+	publishRoadmapReady(userID, roadmap)
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"message":   "Roadmap generated successfully",
@@ -282,6 +284,15 @@ func GenerateRoadmap(c echo.Context) error {
 	})
 }
 
+// publishRoadmapReady tells the user's open streams that a freshly generated roadmap is available
+func publishRoadmapReady(userID string, roadmap models.Roadmap) {
+	publishEvent(userID, "roadmap_ready", map[string]interface{}{
+		"roadmapId":   roadmap.ID,
+		"roadmapType": roadmap.RoadmapType,
+		"title":       roadmap.Title,
+	})
+}
+
 // GetUserRoadmaps retrieves all roadmaps for a user
 func GetUserRoadmaps(c echo.Context) error {
 	// Get user data from context
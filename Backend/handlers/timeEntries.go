@@ -0,0 +1,570 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	timeEntryDescriptionMaxLength = 1000 // Characters per entry description
+	maxHoursPerDay                = 24.0 // Across every project a student works on
+	maxReviewBatch                = 200  // Entries per review request
+)
+
+// timesheetHeader lists the columns of a timesheet export
+var timesheetHeader = []string{
+	"Month", "Student", "Email", "Project ID", "Project", "Supervisor",
+	"Week Starting", "Date", "Hours", "Description", "Status", "Reviewed By", "Reviewed At",
+}
+
+// weekSummary is the logged and approved hours of one week
+type weekSummary struct {
+	WeekStart string  `json:"weekStart"`
+	Hours     float64 `json:"hours"`
+	Approved  float64 `json:"approved"`
+}
+
+// weekStartOf returns the Monday of the week a date falls in
+func weekStartOf(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7
+	return date.AddDate(0, 0, -offset)
+}
+
+// parseTimesheetMonth reads ?month=YYYY-MM, defaulting to the current month, and returns its first day
+// and the first day of the next month
+func parseTimesheetMonth(c echo.Context) (time.Time, time.Time, error) {
+	month := time.Now().UTC()
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if raw := c.QueryParam("month"); raw != "" {
+		parsed, err := time.Parse("2006-01", raw)
+		if err != nil {
+			return month, month, fmt.Errorf("month must be in YYYY-MM format")
+		}
+		month = parsed
+	}
+	return month, month.AddDate(0, 1, 0), nil
+}
+
+// validateTimeEntry checks a logged day, hours and description, returning the parsed work date
+func validateTimeEntry(date string, hours float64, description string) (time.Time, error) {
+	workDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return workDate, fmt.Errorf("date must be in YYYY-MM-DD format")
+	}
+	if workDate.After(startOfToday()) {
+		return workDate, fmt.Errorf("hours cannot be logged for future dates")
+	}
+	if hours <= 0 || hours > maxHoursPerDay {
+		return workDate, fmt.Errorf("hours must be greater than 0 and at most %g", maxHoursPerDay)
+	}
+	// Quarter-hour precision
+	if hours*4 != float64(int(hours*4)) {
+		return workDate, fmt.Errorf("hours must be in quarter-hour increments")
+	}
+	if description == "" || len([]rune(description)) > timeEntryDescriptionMaxLength {
+		return workDate, fmt.Errorf("description is required and cannot be longer than %d characters", timeEntryDescriptionMaxLength)
+	}
+	return workDate, nil
+}
+
+// checkDailyHours makes sure a student's hours on a day stay within maxHoursPerDay; excludeID skips the
+// entry being edited
+func checkDailyHours(uid string, workDate time.Time, hours float64, excludeID uint) error {
+	var logged float64
+	config.DB.Model(&models.TimeEntry{}).
+		Select("COALESCE(SUM(hours), 0)").
+		Where("uid = ? AND work_date = ? AND id <> ? AND status <> ?", uid, workDate, excludeID, models.TimeEntryRejected).
+		Scan(&logged)
+	if logged+hours > maxHoursPerDay {
+		return fmt.Errorf("you have already logged %g hours on %s", logged, workDate.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// findTimeEntry loads the entry in the :entryId path parameter of the project
+func findTimeEntry(c echo.Context, projectID string) (models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := config.DB.Where("id = ? AND p_id = ?", c.Param("entryId"), projectID).First(&entry).Error
+	return entry, err
+}
+
+// findWorkingProject loads the project in the :id path parameter if the user is one of its working users
+func findWorkingProject(c echo.Context, uid string) (models.Projects, error) {
+	var project models.Projects
+	if err := config.DB.Where("project_id = ?", c.Param("id")).First(&project).Error; err != nil {
+		return project, err
+	}
+	if !slices.Contains(project.WorkingUsers, uid) {
+		return project, fmt.Errorf("user %s is not working on project %s", uid, project.ProjectID)
+	}
+	return project, nil
+}
+
+// summarizeWeeks totals entries by week, oldest week first
+func summarizeWeeks(entries []models.TimeEntry) []weekSummary {
+	byWeek := make(map[string]*weekSummary)
+	for _, entry := range entries {
+		key := entry.WeekStart.Format("2006-01-02")
+		summary, ok := byWeek[key]
+		if !ok {
+			summary = &weekSummary{WeekStart: key}
+			byWeek[key] = summary
+		}
+		if entry.Status == models.TimeEntryRejected {
+			continue
+		}
+		summary.Hours += entry.Hours
+		if entry.Status == models.TimeEntryApproved {
+			summary.Approved += entry.Hours
+		}
+	}
+
+	weeks := make([]weekSummary, 0, len(byWeek))
+	for _, summary := range byWeek {
+		weeks = append(weeks, *summary)
+	}
+	sort.Slice(weeks, func(i, j int) bool { return weeks[i].WeekStart < weeks[j].WeekStart })
+	return weeks
+}
+
+// buildTimesheetRows flattens entries into timesheet rows grouped by student and project, each group
+// followed by a total row
+func buildTimesheetRows(month time.Time, entries []models.TimeEntry) [][]string {
+	uids := make([]string, 0, len(entries))
+	projectIDs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		uids = append(uids, entry.UID)
+		if entry.ReviewedBy != "" {
+			uids = append(uids, entry.ReviewedBy)
+		}
+		projectIDs = append(projectIDs, entry.PID)
+	}
+
+	projects := make(map[string]models.Projects)
+	if len(projectIDs) > 0 {
+		var projectRows []models.Projects
+		config.DB.Where("project_id IN ?", projectIDs).Find(&projectRows)
+		for _, project := range projectRows {
+			projects[project.ProjectID] = project
+			uids = append(uids, project.CreatorID)
+		}
+	}
+
+	users := make(map[string]models.User)
+	if len(uids) > 0 {
+		var userRows []models.User
+		config.DB.Select("uid, name, email").Where("uid IN ?", uids).Find(&userRows)
+		for _, user := range userRows {
+			users[user.Uid] = user
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if users[a.UID].Name != users[b.UID].Name {
+			return users[a.UID].Name < users[b.UID].Name
+		}
+		if a.UID != b.UID {
+			return a.UID < b.UID
+		}
+		if a.PID != b.PID {
+			return a.PID < b.PID
+		}
+		if !a.WorkDate.Equal(b.WorkDate) {
+			return a.WorkDate.Before(b.WorkDate)
+		}
+		return a.ID < b.ID
+	})
+
+	monthLabel := month.Format("2006-01")
+	rows := make([][]string, 0, len(entries)+1)
+	var total float64
+	for i, entry := range entries {
+		student := users[entry.UID]
+		project := projects[entry.PID]
+		supervisor := users[project.CreatorID].Name
+
+		reviewedAt := ""
+		if entry.ReviewedAt != nil {
+			reviewedAt = entry.ReviewedAt.Format(time.RFC3339)
+		}
+
+		rows = append(rows, []string{
+			monthLabel, student.Name, student.Email, project.ProjectID, project.Name, supervisor,
+			entry.WeekStart.Format("2006-01-02"), entry.WorkDate.Format("2006-01-02"), strconv.FormatFloat(entry.Hours, 'f', 2, 64),
+			entry.Description, entry.Status, users[entry.ReviewedBy].Name, reviewedAt,
+		})
+		total += entry.Hours
+
+		// Close the student's group for this project
+		if i == len(entries)-1 || entries[i+1].UID != entry.UID || entries[i+1].PID != entry.PID {
+			rows = append(rows, []string{
+				monthLabel, student.Name, student.Email, project.ProjectID, project.Name, supervisor,
+				"", "Total", strconv.FormatFloat(total, 'f', 2, 64), "", "", "", "",
+			})
+			total = 0
+		}
+	}
+	return rows
+}
+
+// LogProjectHours lets a working user log hours worked on a project on one day
+func LogProjectHours(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findWorkingProject(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you are not working on it"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Date        string  `json:"date"`
+		Hours       float64 `json:"hours"`
+		Description string  `json:"description"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	description := strings.TrimSpace(requestBody.Description)
+	workDate, err := validateTimeEntry(requestBody.Date, requestBody.Hours, description)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+	if err := checkDailyHours(userData.GetUID(), workDate, requestBody.Hours, 0); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	entry := models.TimeEntry{
+		PID:         project.ProjectID,
+		UID:         userData.GetUID(),
+		WorkDate:    workDate,
+		WeekStart:   weekStartOf(workDate),
+		Hours:       requestBody.Hours,
+		Description: description,
+		Status:      models.TimeEntryPending,
+	}
+	if err := config.DB.Create(&entry).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to log hours"})
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Hours logged",
+		"entry":   entry,
+	})
+}
+
+// GetProjectHours lists a month of logged hours (?month=YYYY-MM) with weekly totals. Working users see their
+// own entries; owners and reviewers see everyone's and may filter by ?uid= and ?status=.
+func GetProjectHours(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, moderator, err := loadMemberProject(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you are not a member"})
+	}
+
+	monthStart, monthEnd, err := parseTimesheetMonth(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	query := config.DB.Where("p_id = ? AND work_date >= ? AND work_date < ?", project.ProjectID, monthStart, monthEnd)
+	if !moderator {
+		query = query.Where("uid = ?", userData.GetUID())
+	} else if uid := c.QueryParam("uid"); uid != "" {
+		query = query.Where("uid = ?", uid)
+	}
+	if status := c.QueryParam("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	entries := []models.TimeEntry{}
+	if err := query.Order("work_date ASC, id ASC").Find(&entries).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch hours"})
+	}
+
+	totals := map[string]float64{models.TimeEntryPending: 0, models.TimeEntryApproved: 0, models.TimeEntryRejected: 0}
+	uids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		totals[entry.Status] += entry.Hours
+		uids = append(uids, entry.UID)
+	}
+	participants := loadParticipants(uids)
+
+	results := make([]echo.Map, 0, len(entries))
+	for _, entry := range entries {
+		results = append(results, echo.Map{
+			"entry":   entry,
+			"student": participants[entry.UID],
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"month":   monthStart.Format("2006-01"),
+		"entries": results,
+		"count":   len(results),
+		"weeks":   summarizeWeeks(entries),
+		"totals":  totals,
+	})
+}
+
+// UpdateProjectHours lets a student correct their own entry; approved entries are locked and a
+// corrected rejected entry goes back for review
+func UpdateProjectHours(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findWorkingProject(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you are not working on it"})
+	}
+
+	entry, err := findTimeEntry(c, project.ProjectID)
+	if err != nil || entry.UID != userData.GetUID() {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Entry not found"})
+	}
+	if entry.Status == models.TimeEntryApproved {
+		return c.JSON(http.StatusConflict, echo.Map{"error": "Approved entries cannot be changed"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Date        *string  `json:"date"`
+		Hours       *float64 `json:"hours"`
+		Description *string  `json:"description"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	date := entry.WorkDate.Format("2006-01-02")
+	if requestBody.Date != nil {
+		date = *requestBody.Date
+	}
+	hours := entry.Hours
+	if requestBody.Hours != nil {
+		hours = *requestBody.Hours
+	}
+	description := entry.Description
+	if requestBody.Description != nil {
+		description = strings.TrimSpace(*requestBody.Description)
+	}
+
+	workDate, err := validateTimeEntry(date, hours, description)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+	if err := checkDailyHours(userData.GetUID(), workDate, hours, entry.ID); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	updates := map[string]interface{}{
+		"work_date":   workDate,
+		"week_start":  weekStartOf(workDate),
+		"hours":       hours,
+		"description": description,
+		"status":      models.TimeEntryPending,
+		"reviewed_by": "",
+		"reviewed_at": nil,
+		"review_note": "",
+	}
+	if err := config.DB.Model(&entry).Updates(updates).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update entry"})
+	}
+	config.DB.First(&entry, entry.ID)
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Entry updated",
+		"entry":   entry,
+	})
+}
+
+// DeleteProjectHours lets a student delete their own entry unless it has been approved
+func DeleteProjectHours(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findWorkingProject(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you are not working on it"})
+	}
+
+	entry, err := findTimeEntry(c, project.ProjectID)
+	if err != nil || entry.UID != userData.GetUID() {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Entry not found"})
+	}
+	if entry.Status == models.TimeEntryApproved {
+		return c.JSON(http.StatusConflict, echo.Map{"error": "Approved entries cannot be deleted"})
+	}
+
+	if err := config.DB.Delete(&entry).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to delete entry"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "Entry deleted"})
+}
+
+// ReviewProjectHours approves or rejects a batch of a project's entries and tells each student the outcome
+func ReviewProjectHours(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, err := findReviewableProject(c.Param("id"), userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		EntryIDs []uint `json:"entryIds"`
+		Status   string `json:"status"`
+		Note     string `json:"note"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if requestBody.Status != models.TimeEntryApproved && requestBody.Status != models.TimeEntryRejected {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Status must be approved or rejected"})
+	}
+	if len(requestBody.EntryIDs) == 0 || len(requestBody.EntryIDs) > maxReviewBatch {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("Between 1 and %d entries can be reviewed at once", maxReviewBatch)})
+	}
+	note := strings.TrimSpace(requestBody.Note)
+	if requestBody.Status == models.TimeEntryRejected && note == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "A note is required when rejecting hours"})
+	}
+
+	var entries []models.TimeEntry
+	if err := config.DB.Where("p_id = ? AND id IN ?", project.ProjectID, requestBody.EntryIDs).Find(&entries).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch entries"})
+	}
+	if len(entries) != len(requestBody.EntryIDs) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Some entries were not found on this project"})
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":      requestBody.Status,
+		"reviewed_by": userData.GetUID(),
+		"reviewed_at": now,
+		"review_note": note,
+	}
+	if err := config.DB.Model(&models.TimeEntry{}).Where("p_id = ? AND id IN ?", project.ProjectID, requestBody.EntryIDs).Updates(updates).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to review entries"})
+	}
+
+	// One notification per student, with the hours that were reviewed
+	hoursByStudent := make(map[string]float64)
+	var students []string
+	for _, entry := range entries {
+		if _, ok := hoursByStudent[entry.UID]; !ok {
+			students = append(students, entry.UID)
+		}
+		hoursByStudent[entry.UID] += entry.Hours
+	}
+	notifications := make([]models.Notification, 0, len(students))
+	for _, uid := range students {
+		body := fmt.Sprintf("%g logged hours on %s were %s.", hoursByStudent[uid], project.Name, requestBody.Status)
+		if note != "" {
+			body += " " + note
+		}
+		notifications = append(notifications, models.Notification{
+			RecipientUID: uid,
+			Type:         models.NotificationHoursReviewed,
+			Title:        fmt.Sprintf("Hours %s on %s", requestBody.Status, project.Name),
+			Body:         body,
+			PID:          project.ProjectID,
+		})
+	}
+	createNotifications(notifications)
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":  fmt.Sprintf("%d entries %s", len(entries), requestBody.Status),
+		"reviewed": len(entries),
+	})
+}
+
+// ExportProjectTimesheet exports a month of a project's approved hours (?status=all for every entry) as
+// CSV or XLSX. Owners and reviewers may export everyone or one student (?uid=); working users export their own.
+func ExportProjectTimesheet(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	project, moderator, err := loadMemberProject(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you are not a member"})
+	}
+
+	format, ok := parseExportFormat(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Format must be csv or xlsx"})
+	}
+
+	monthStart, monthEnd, err := parseTimesheetMonth(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	query := config.DB.Where("p_id = ? AND work_date >= ? AND work_date < ?", project.ProjectID, monthStart, monthEnd)
+	if !moderator {
+		query = query.Where("uid = ?", userData.GetUID())
+	} else if uid := c.QueryParam("uid"); uid != "" {
+		query = query.Where("uid = ?", uid)
+	}
+	if c.QueryParam("status") != "all" {
+		query = query.Where("status = ?", models.TimeEntryApproved)
+	}
+
+	var entries []models.TimeEntry
+	if err := query.Find(&entries).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch hours"})
+	}
+
+	rows := buildTimesheetRows(monthStart, entries)
+	filename := fmt.Sprintf("timesheet-%s-%s", project.ProjectID, monthStart.Format("2006-01"))
+	return writeSpreadsheet(c, format, filename, "Timesheet", timesheetHeader, rows)
+}
+
+// ExportTimesheets exports a month of approved hours across every project for administration
+func ExportTimesheets(c echo.Context) error {
+	format, ok := parseExportFormat(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Format must be csv or xlsx"})
+	}
+
+	monthStart, monthEnd, err := parseTimesheetMonth(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	query := config.DB.Where("status = ? AND work_date >= ? AND work_date < ?", models.TimeEntryApproved, monthStart, monthEnd)
+	if uid := c.QueryParam("uid"); uid != "" {
+		query = query.Where("uid = ?", uid)
+	}
+
+	var entries []models.TimeEntry
+	if err := query.Find(&entries).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch hours"})
+	}
+
+	rows := buildTimesheetRows(monthStart, entries)
+	filename := fmt.Sprintf("timesheets-%s", monthStart.Format("2006-01"))
+	return writeSpreadsheet(c, format, filename, "Timesheets", timesheetHeader, rows)
+}
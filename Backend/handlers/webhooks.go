@@ -0,0 +1,569 @@
+package handlers
+
+import (
+	"backend/config"
+	"backend/models"
+	"backend/utils"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
+)
+
+const (
+	webhookMaxSubscriptions = 10               // Per user
+	webhookMaxAttempts      = 6                // A delivery is marked failed after this many attempts
+	webhookRetryBase        = time.Minute      // First retry delay; doubles on each attempt (1m, 2m, 4m, 8m, 16m)
+	webhookClaimLease       = 2 * time.Minute  // A claimed delivery is picked up again if its sender dies mid-request
+	webhookRetryInterval    = 30 * time.Second // How often due retries are sent
+)
+
+// webhookEventTypes lists the events a subscription can ask for
+var webhookEventTypes = []string{
+	models.WebhookApplicationCreated,
+	models.WebhookApplicationStatusChanged,
+	models.WebhookProjectCreated,
+	models.WebhookWorkingUserRemoved,
+}
+
+// isWebhookEventType reports whether eventType is one of webhookEventTypes
+func isWebhookEventType(eventType string) bool {
+	for _, t := range webhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookEnvelope is the JSON body posted to subscribers
+type webhookEnvelope struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Data      interface{} `json:"data"`
+}
+
+// webhookProjectData is the project summary included in application and membership events
+func webhookProjectData(project models.Projects) echo.Map {
+	return echo.Map{
+		"pid":       project.ProjectID,
+		"name":      project.Name,
+		"creatorId": project.CreatorID,
+	}
+}
+
+// webhookApplicationData is the payload of application events; the applicant's ID is left out while
+// the project is in blind review, as in the professor's application list
+func webhookApplicationData(application models.ProjRequests, project models.Projects) echo.Map {
+	applicationData := echo.Map{
+		"id":          application.ID,
+		"pid":         application.PID,
+		"status":      application.Status,
+		"timeCreated": application.TimeCreated,
+	}
+	if !isApplicantHidden(project, application.Status) {
+		applicationData["uid"] = application.UID
+	}
+	return echo.Map{
+		"application": applicationData,
+		"project":     webhookProjectData(project),
+	}
+}
+
+// emitApplicationStatusChanged sends the application.status_changed event for an application moved from previousStatus
+func emitApplicationStatusChanged(application models.ProjRequests, project models.Projects, previousStatus, status string) {
+	application.Status = status
+	data := webhookApplicationData(application, project)
+	data["previousStatus"] = previousStatus
+	emitWebhookEvent(models.WebhookApplicationStatusChanged, project, []string{application.UID}, data)
+}
+
+// emitWorkingUserRemoved sends the working_user.removed event for a member taken off a project
+func emitWorkingUserRemoved(project models.Projects, uid string) {
+	emitWebhookEvent(models.WebhookWorkingUserRemoved, project, []string{uid}, echo.Map{
+		"uid":     uid,
+		"project": webhookProjectData(project),
+	})
+}
+
+// emitWebhookEvent queues an event for every active subscription that asked for it and sends it in the
+// background. Project subscriptions match the project's events while their owner still runs or reviews it;
+// user subscriptions match events that concern their owner: the project's creator and reviewers, plus uids.
+func emitWebhookEvent(eventType string, project models.Projects, uids []string, data interface{}) {
+	staff := append([]string{project.CreatorID}, project.Reviewers...)
+	audience := append(append([]string{}, staff...), uids...)
+
+	var subscriptions []models.WebhookSubscription
+	if err := config.DB.
+		Where("active = ? AND ? = ANY(events)", true, eventType).
+		Where("(p_id = ? AND owner_uid IN ?) OR (COALESCE(p_id, '') = '' AND owner_uid IN ?)", project.ProjectID, staff, audience).
+		Find(&subscriptions).Error; err != nil {
+		log.Printf("Failed to load webhook subscriptions for %s: %v", eventType, err)
+		return
+	}
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	eventID, err := utils.GenerateEventID()
+	if err != nil {
+		log.Printf("Failed to generate webhook event ID: %v", err)
+		return
+	}
+	now := time.Now()
+	payload, err := json.Marshal(webhookEnvelope{ID: eventID, Type: eventType, CreatedAt: now, Data: data})
+	if err != nil {
+		log.Printf("Failed to encode %s webhook payload: %v", eventType, err)
+		return
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      eventType,
+			Payload:        string(payload),
+			Status:         models.WebhookDeliveryPending,
+			NextAttemptAt:  &now,
+		})
+	}
+	if err := config.DB.Create(&deliveries).Error; err != nil {
+		log.Printf("Failed to queue %s webhooks: %v", eventType, err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		go deliverWebhook(delivery.ID)
+	}
+}
+
+// webhookRetryDelay is the wait before the next attempt after attempts failed ones
+func webhookRetryDelay(attempts int) time.Duration {
+	return webhookRetryBase << (attempts - 1)
+}
+
+// deliverWebhook makes one attempt at a pending delivery and schedules a retry with exponential backoff
+// if the receiver does not answer 2xx. The delivery is claimed first so the retry job and an immediate
+// send never post it twice.
+func deliverWebhook(deliveryID uint) {
+	now := time.Now()
+	claim := config.DB.Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", deliveryID, models.WebhookDeliveryPending, now).
+		Update("next_attempt_at", now.Add(webhookClaimLease))
+	if claim.Error != nil || claim.RowsAffected == 0 {
+		return
+	}
+
+	var delivery models.WebhookDelivery
+	if err := config.DB.First(&delivery, deliveryID).Error; err != nil {
+		return
+	}
+
+	var subscription models.WebhookSubscription
+	if err := config.DB.First(&subscription, delivery.SubscriptionID).Error; err != nil || !subscription.Active {
+		config.DB.Model(&delivery).Updates(map[string]interface{}{
+			"status":          models.WebhookDeliveryFailed,
+			"next_attempt_at": nil,
+			"last_error":      "Subscription is inactive",
+		})
+		return
+	}
+
+	responseStatus, sendErr := utils.SendWebhook(utils.WebhookRequest{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		DeliveryID: delivery.ID,
+		Payload:    []byte(delivery.Payload),
+	})
+
+	attempts := delivery.Attempts + 1
+	updates := map[string]interface{}{
+		"attempts":        attempts,
+		"response_status": responseStatus,
+	}
+	switch {
+	case sendErr == nil:
+		updates["status"] = models.WebhookDeliverySucceeded
+		updates["next_attempt_at"] = nil
+		updates["delivered_at"] = time.Now()
+		updates["last_error"] = ""
+	case attempts >= webhookMaxAttempts:
+		updates["status"] = models.WebhookDeliveryFailed
+		updates["next_attempt_at"] = nil
+		updates["last_error"] = sendErr.Error()
+	default:
+		updates["next_attempt_at"] = time.Now().Add(webhookRetryDelay(attempts))
+		updates["last_error"] = sendErr.Error()
+	}
+
+	if err := config.DB.Model(&delivery).Updates(updates).Error; err != nil {
+		log.Printf("Failed to record webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// retryDueWebhooks sends every pending delivery whose next attempt is due
+func retryDueWebhooks() {
+	var ids []uint
+	if err := config.DB.Model(&models.WebhookDelivery{}).
+		Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, time.Now()).
+		Order("next_attempt_at").Limit(100).Pluck("id", &ids).Error; err != nil {
+		log.Printf("Failed to load due webhook deliveries: %v", err)
+		return
+	}
+
+	for _, id := range ids {
+		deliverWebhook(id)
+	}
+}
+
+// StartWebhookRetries starts a goroutine that retries failed webhook deliveries
+func StartWebhookRetries() {
+	ticker := time.NewTicker(webhookRetryInterval)
+	go func() {
+		for range ticker.C {
+			retryDueWebhooks()
+		}
+	}()
+}
+
+// validateWebhookURL reports a problem with a subscriber URL, or "" if it is usable
+func validateWebhookURL(rawURL string) string {
+	if !isHTTPLink(rawURL) {
+		return "url must be an absolute http or https URL"
+	}
+	if err := utils.CheckWebhookHost(rawURL); err != nil {
+		if errors.Is(err, utils.ErrWebhookAddressBlocked) {
+			return "url must point to a public host; private, loopback and link-local addresses are not allowed"
+		}
+		return "url host could not be resolved"
+	}
+	return ""
+}
+
+// normalizeWebhookEvents de-duplicates event types, returning the unknown one if there is any
+func normalizeWebhookEvents(events []string) (pq.StringArray, string) {
+	seen := make(map[string]bool, len(events))
+	normalized := pq.StringArray{}
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		if !isWebhookEventType(event) {
+			return nil, event
+		}
+		if !seen[event] {
+			seen[event] = true
+			normalized = append(normalized, event)
+		}
+	}
+	return normalized, ""
+}
+
+// findMyWebhook loads the subscription in the :id path parameter if the user owns it
+func findMyWebhook(c echo.Context, uid string) (models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := config.DB.Where("id = ? AND owner_uid = ?", c.Param("id"), uid).First(&subscription).Error
+	return subscription, err
+}
+
+// GetMyWebhooks lists the user's webhook subscriptions and the events they can subscribe to
+func GetMyWebhooks(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	subscriptions := []models.WebhookSubscription{}
+	if err := config.DB.Where("owner_uid = ?", userData.GetUID()).Order("created_at DESC").Find(&subscriptions).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch webhooks"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"webhooks":   subscriptions,
+		"count":      len(subscriptions),
+		"eventTypes": webhookEventTypes,
+	})
+}
+
+// CreateWebhook subscribes a URL to events, either for one project the user runs or reviews or for
+// everything that concerns the user. The signing secret is only returned here and on rotation.
+func CreateWebhook(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	// Parse request body
+	var requestBody struct {
+		URL    string   `json:"url"`
+		Events []string `json:"events"`
+		PID    string   `json:"pid"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	requestBody.URL = strings.TrimSpace(requestBody.URL)
+	if msg := validateWebhookURL(requestBody.URL); msg != "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": msg})
+	}
+
+	events, unknown := normalizeWebhookEvents(requestBody.Events)
+	if unknown != "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Unknown event type: " + unknown})
+	}
+	if len(events) == 0 {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "At least one event type is required"})
+	}
+
+	if requestBody.PID != "" {
+		if _, err := findReviewableProject(requestBody.PID, userData.GetUID()); err != nil {
+			return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found or you don't have permission"})
+		}
+	}
+
+	var count int64
+	config.DB.Model(&models.WebhookSubscription{}).Where("owner_uid = ?", userData.GetUID()).Count(&count)
+	if count >= webhookMaxSubscriptions {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "You can have at most " + strconv.Itoa(webhookMaxSubscriptions) + " webhooks"})
+	}
+
+	secret, err := utils.GenerateWebhookSecret()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to generate webhook secret"})
+	}
+
+	subscription := models.WebhookSubscription{
+		OwnerUID: userData.GetUID(),
+		PID:      requestBody.PID,
+		URL:      requestBody.URL,
+		Secret:   secret,
+		Events:   events,
+		Active:   true,
+	}
+	if err := config.DB.Create(&subscription).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to create webhook"})
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"message": "Webhook created. Store the secret now; it will not be shown again",
+		"webhook": subscription,
+		"secret":  secret,
+	})
+}
+
+// UpdateWebhook changes a subscription's URL, events or active flag
+func UpdateWebhook(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	subscription, err := findMyWebhook(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Webhook not found"})
+	}
+
+	// Parse request body
+	var requestBody struct {
+		URL    *string   `json:"url"`
+		Events *[]string `json:"events"`
+		Active *bool     `json:"active"`
+	}
+
+	if err := c.Bind(&requestBody); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	updates := make(map[string]interface{})
+	if requestBody.URL != nil {
+		webhookURL := strings.TrimSpace(*requestBody.URL)
+		if msg := validateWebhookURL(webhookURL); msg != "" {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": msg})
+		}
+		updates["url"] = webhookURL
+	}
+	if requestBody.Events != nil {
+		events, unknown := normalizeWebhookEvents(*requestBody.Events)
+		if unknown != "" {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Unknown event type: " + unknown})
+		}
+		if len(events) == 0 {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "At least one event type is required"})
+		}
+		updates["events"] = events
+	}
+	if requestBody.Active != nil {
+		updates["active"] = *requestBody.Active
+	}
+
+	if len(updates) > 0 {
+		if err := config.DB.Model(&subscription).Updates(updates).Error; err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update webhook"})
+		}
+		config.DB.First(&subscription, subscription.ID)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Webhook updated successfully",
+		"webhook": subscription,
+	})
+}
+
+// DeleteWebhook removes a subscription and its delivery log
+func DeleteWebhook(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	subscription, err := findMyWebhook(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Webhook not found"})
+	}
+
+	tx := config.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Where("subscription_id = ?", subscription.ID).Delete(&models.WebhookDelivery{}).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to delete webhook deliveries"})
+	}
+	if err := tx.Delete(&subscription).Error; err != nil {
+		tx.Rollback()
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to delete webhook"})
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to save changes"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"message": "Webhook deleted successfully"})
+}
+
+// RotateWebhookSecret replaces a subscription's signing secret; deliveries from now on use the new one
+func RotateWebhookSecret(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	subscription, err := findMyWebhook(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Webhook not found"})
+	}
+
+	secret, err := utils.GenerateWebhookSecret()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to generate webhook secret"})
+	}
+	if err := config.DB.Model(&subscription).Update("secret", secret).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to rotate webhook secret"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "Webhook secret rotated. Store the secret now; it will not be shown again",
+		"secret":  secret,
+	})
+}
+
+// GetWebhookDeliveries returns a page of a subscription's delivery log, newest first
+func GetWebhookDeliveries(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	subscription, err := findMyWebhook(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Webhook not found"})
+	}
+
+	// Get pagination parameters
+	page := 1
+	pageSize := 20
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if pageSizeParam := c.QueryParam("pageSize"); pageSizeParam != "" {
+		if ps, err := strconv.Atoi(pageSizeParam); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+
+	query := config.DB.Model(&models.WebhookDelivery{}).Where("subscription_id = ?", subscription.ID)
+	if status := c.QueryParam("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if eventType := c.QueryParam("eventType"); eventType != "" {
+		query = query.Where("event_type = ?", eventType)
+	}
+
+	// Get total count before pagination
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to count deliveries"})
+	}
+
+	// Apply pagination
+	deliveries := []models.WebhookDelivery{}
+	offset := (page - 1) * pageSize
+	if err := query.Offset(offset).Limit(pageSize).Order("created_at DESC, id DESC").Find(&deliveries).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to fetch deliveries"})
+	}
+
+	totalPages := int((totalCount + int64(pageSize) - 1) / int64(pageSize))
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"deliveries": deliveries,
+		"count":      len(deliveries),
+		"total":      totalCount,
+		"page":       page,
+		"pageSize":   pageSize,
+		"totalPages": totalPages,
+	})
+}
+
+// ReplayWebhookDelivery sends a logged event again as a new delivery with the same event ID, so receivers
+// that de-duplicate on it can tell a replay from a new event
+func ReplayWebhookDelivery(c echo.Context) error {
+	// Get user data from context
+	userData := c.Get("userData").(models.UserData)
+
+	subscription, err := findMyWebhook(c, userData.GetUID())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Webhook not found"})
+	}
+	if !subscription.Active {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Activate the webhook before replaying deliveries"})
+	}
+
+	var original models.WebhookDelivery
+	if err := config.DB.Where("id = ? AND subscription_id = ?", c.Param("deliveryId"), subscription.ID).First(&original).Error; err != nil {
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Delivery not found"})
+	}
+
+	now := time.Now()
+	replay := models.WebhookDelivery{
+		SubscriptionID: subscription.ID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		Status:         models.WebhookDeliveryPending,
+		NextAttemptAt:  &now,
+		ReplayOf:       &original.ID,
+	}
+	if err := config.DB.Create(&replay).Error; err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to queue replay"})
+	}
+
+	go deliverWebhook(replay.ID)
+
+	return c.JSON(http.StatusAccepted, echo.Map{
+		"message":  "Replay queued",
+		"delivery": replay,
+	})
+}
@@ -12,6 +12,14 @@ type CProj struct {
 	Duration       string   `json:"duration"`
 	PositionType   []string `json:"positionType"`
 	Deadline       *string  `json:"deadline"`
+	BlindReview    bool     `json:"blindReview"`
+	Reviewers      []string `json:"reviewers"`
+
+	// Eligibility rules
+	MinYear                 int      `json:"minYear"`
+	AllowedDegrees          []string `json:"allowedDegrees"`
+	RequiredSkills          []string `json:"requiredSkills"`
+	RequiredProfileSections []string `json:"requiredProfileSections"`
 }
 
 type UpdateProj struct {
@@ -26,4 +34,12 @@ type UpdateProj struct {
 	Duration       *string   `json:"duration"`
 	PositionType   *[]string `json:"positionType"`
 	Deadline       *string   `json:"deadline"`
+	BlindReview    *bool     `json:"blindReview"`
+	Reviewers      *[]string `json:"reviewers"`
+
+	// Eligibility rules
+	MinYear                 *int      `json:"minYear"`
+	AllowedDegrees          *[]string `json:"allowedDegrees"`
+	RequiredSkills          *[]string `json:"requiredSkills"`
+	RequiredProfileSections *[]string `json:"requiredProfileSections"`
 }
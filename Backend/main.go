@@ -33,12 +33,64 @@ func main() {
 		&models.PlacementPreference{},
 		&models.Roadmap{},
 		&models.RoadmapCache{},
+		&models.ApplicationMessage{},
+		&models.ApplicationNote{},
+		&models.ApplicationTag{},
+		&models.AvailabilityWindow{},
+		&models.CalendarFeedToken{},
+		&models.ApplicationDraft{},
+		&models.RecommendationRequest{},
+		&models.Notification{},
+		&models.NotificationPreference{},
+		&models.NotificationSettings{},
+		&models.NotificationDigestEntry{},
+		&models.ProjectMatchSubscription{},
+		&models.ProjectMatchSent{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.Conversation{},
+		&models.DirectMessage{},
+		&models.ProjectQuestion{},
+		&models.ProjectAnswer{},
+		&models.ProjectQuestionVote{},
+		&models.ProjectAnnouncement{},
+		&models.AnnouncementAck{},
+		&models.ProjectMilestone{},
+		&models.ProjectTask{},
+		&models.TaskComment{},
+		&models.TimeEntry{},
+		&models.ProjectCompletion{},
+		&models.ProjectReview{},
 	)
 
 	// Start cache cleanup goroutine for recommendations
 	handlers.StartCacheCleanup()
 	log.Println("✅ Recommendation cache cleanup started")
 
+	// Start draft expiry goroutine for closed projects
+	handlers.StartDraftExpiry()
+	log.Println("✅ Application draft expiry started")
+
+	// Start reminder goroutine for pending recommendation letters
+	handlers.StartRecommendationReminders()
+	log.Println("✅ Recommendation letter reminders started")
+
+	// Start retention goroutine for in-app notifications
+	handlers.StartNotificationRetention()
+	log.Println("✅ Notification retention started")
+
+	// Start scheduler for daily and weekly notification digests
+	handlers.StartNotificationDigests()
+	log.Println("✅ Notification digests started")
+
+	// Start scheduler for the weekly new matching projects email
+	handlers.StartProjectMatchDigests()
+	log.Println("✅ Project match digests started")
+
+	// Start retry goroutine for outgoing webhooks
+	handlers.StartWebhookRetries()
+	log.Println("✅ Webhook retries started")
+
 	// Initialize Echo
 	e := echo.New()
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
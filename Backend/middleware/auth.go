@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"os"
 	"strings"
 
 	"backend/models"
@@ -58,6 +59,42 @@ func JWTMiddleware() echo.MiddlewareFunc {
 	}
 }
 
+// StreamTokenMiddleware authenticates the event stream. EventSource cannot set headers, so it passes a
+// short-lived stream token as ?token=; clients that can send the Authorization header use their session JWT.
+func StreamTokenMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withSession := JWTMiddleware()(next)
+		return func(c echo.Context) error {
+			if c.Request().Header.Get("Authorization") != "" {
+				return withSession(c)
+			}
+
+			// Validate token
+			claims, err := utils.ValidateStreamToken(c.QueryParam("token"))
+			if err != nil {
+				return c.JSON(http.StatusUnauthorized, echo.Map{
+					"error": "Invalid or expired stream token",
+				})
+			}
+
+			// Create user data object from claims
+			userData := &models.AuthenticatedUser{
+				UID:   claims.UserID,
+				Email: claims.Email,
+				Type:  claims.Type,
+				Name:  "", // Name not available in JWT claims
+			}
+
+			// Add user data to context for use in handlers
+			c.Set("userData", userData)
+			c.Set("claims", claims)
+
+			// Continue to next handler
+			return next(c)
+		}
+	}
+}
+
 // OptionalJWTMiddleware validates JWT tokens if present but allows requests without tokens
 func OptionalJWTMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -125,3 +162,29 @@ func RequireUserType(allowedTypes ...string) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// RequireAdmin ensures the authenticated user is listed in ADMIN_EMAILS (comma-separated)
+func RequireAdmin() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// Get user data from context (set by JWTMiddleware)
+			userDataInterface := c.Get("userData")
+			if userDataInterface == nil {
+				return c.JSON(http.StatusUnauthorized, echo.Map{
+					"error": "User not authenticated",
+				})
+			}
+
+			email := userDataInterface.(models.UserData).GetEmail()
+			for _, admin := range strings.Split(os.Getenv("ADMIN_EMAILS"), ",") {
+				if admin = strings.TrimSpace(admin); admin != "" && strings.EqualFold(admin, email) {
+					return next(c)
+				}
+			}
+
+			return c.JSON(http.StatusForbidden, echo.Map{
+				"error": "Insufficient permissions",
+			})
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ApplicationDraft is an unsubmitted application a student is still writing; faculty never see drafts
+type ApplicationDraft struct {
+	gorm.Model
+	UID              string     `json:"uid" gorm:"column:uid;uniqueIndex:idx_application_drafts_uid_pid;not null"`
+	PID              string     `json:"pid" gorm:"column:p_id;uniqueIndex:idx_application_drafts_uid_pid;index;not null"`
+	Availability     string     `json:"availability" gorm:"type:text"`
+	Motivation       string     `json:"motivation" gorm:"type:text"`
+	PriorProjects    string     `json:"priorProjects" gorm:"type:text"`
+	CVLink           string     `json:"cvLink"`
+	PublicationsLink string     `json:"publicationsLink"`
+	ExpiredAt        *time.Time `json:"expiredAt" gorm:"index"` // Set once the project closes; expired drafts are read-only
+}
+
+// TableName specifies the table name for ApplicationDraft
+func (ApplicationDraft) TableName() string {
+	return "application_drafts"
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ApplicationMessage is a single entry in the feedback thread attached to a ProjRequests row
+type ApplicationMessage struct {
+	gorm.Model
+	ApplicationID uint       `json:"applicationId" gorm:"index;not null"`
+	PID           string     `json:"pid" gorm:"column:p_id;index;not null"`
+	SenderUID     string     `json:"senderUid" gorm:"index;not null"`
+	SenderType    UserType   `json:"senderType" gorm:"type:varchar(3);check:sender_type IN ('fac','stu')"`
+	Body          string     `json:"body" gorm:"type:text;not null"`
+	ReadAt        *time.Time `json:"readAt"` // Set when the other side opens the thread
+}
+
+// TableName specifies the table name for ApplicationMessage
+func (ApplicationMessage) TableName() string {
+	return "application_messages"
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ApplicationNote is a private note left on an application by a project owner or reviewer
+type ApplicationNote struct {
+	gorm.Model
+	ApplicationID uint   `json:"applicationId" gorm:"index;not null"`
+	PID           string `json:"pid" gorm:"column:p_id;index;not null"`
+	AuthorUID     string `json:"authorUid" gorm:"index;not null"`
+	Body          string `json:"body" gorm:"type:text;not null"`
+}
+
+// TableName specifies the table name for ApplicationNote
+func (ApplicationNote) TableName() string {
+	return "application_notes"
+}
+
+// ApplicationTag is a custom label attached to an application by a project owner or reviewer
+type ApplicationTag struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	ApplicationID uint      `json:"applicationId" gorm:"uniqueIndex:idx_application_tag;not null"`
+	PID           string    `json:"pid" gorm:"column:p_id;index;not null"`
+	Label         string    `json:"label" gorm:"uniqueIndex:idx_application_tag;size:50;not null"`
+	CreatedBy     string    `json:"createdBy" gorm:"not null"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// TableName specifies the table name for ApplicationTag
+func (ApplicationTag) TableName() string {
+	return "application_tags"
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// CalendarFeedToken grants read-only access to a user's ICS feed without a login
+type CalendarFeedToken struct {
+	gorm.Model
+	UID   string `json:"uid" gorm:"uniqueIndex;not null"`
+	Token string `json:"-" gorm:"uniqueIndex;type:varchar(64);not null"`
+}
+
+// TableName specifies the table name for CalendarFeedToken
+func (CalendarFeedToken) TableName() string {
+	return "calendar_feed_tokens"
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Conversation is a one-to-one message thread; UserA is always the lower UID so each pair has one row
+type Conversation struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	UserA         string    `json:"userA" gorm:"uniqueIndex:idx_conversation_pair;not null"`
+	UserB         string    `json:"userB" gorm:"uniqueIndex:idx_conversation_pair;index;not null"`
+	LastMessageAt time.Time `json:"lastMessageAt" gorm:"index"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// TableName specifies the table name for Conversation
+func (Conversation) TableName() string {
+	return "conversations"
+}
+
+// OtherParticipant returns the UID of the participant who is not uid
+func (c Conversation) OtherParticipant(uid string) string {
+	if c.UserA == uid {
+		return c.UserB
+	}
+	return c.UserA
+}
+
+// DirectMessage is one message of a Conversation. Files are shared as links rather than uploaded.
+type DirectMessage struct {
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	ConversationID uint           `json:"conversationId" gorm:"index:idx_direct_messages_conversation_created,priority:1;not null"`
+	SenderUID      string         `json:"senderUid" gorm:"index;not null"`
+	RecipientUID   string         `json:"recipientUid" gorm:"index;not null"`
+	Body           string         `json:"body" gorm:"type:text;not null"`
+	Attachments    pq.StringArray `json:"attachments" gorm:"type:text[]"`
+	ReadAt         *time.Time     `json:"readAt"` // Set when the recipient opens the conversation
+	CreatedAt      time.Time      `json:"createdAt" gorm:"index:idx_direct_messages_conversation_created,priority:2"`
+}
+
+// TableName specifies the table name for DirectMessage
+func (DirectMessage) TableName() string {
+	return "direct_messages"
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+)
+
+// Email delivery modes for a notification event type
+const (
+	DeliveryImmediate = "immediate"
+	DeliveryDaily     = "daily"
+	DeliveryWeekly    = "weekly"
+)
+
+// NotificationPreference overrides how one event type reaches a user; without a row both channels are on and email is immediate.
+// Booleans carry no gorm default so that false is stored as given.
+type NotificationPreference struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	UserUID      string    `json:"userUid" gorm:"uniqueIndex:idx_notification_preference_user_event;not null"`
+	EventType    string    `json:"eventType" gorm:"type:varchar(40);uniqueIndex:idx_notification_preference_user_event;not null"`
+	EmailEnabled bool      `json:"emailEnabled" gorm:"not null"`
+	InAppEnabled bool      `json:"inAppEnabled" gorm:"not null"`
+	Delivery     string    `json:"delivery" gorm:"type:varchar(10);not null;check:delivery IN ('immediate','daily','weekly')"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// TableName specifies the table name for NotificationPreference
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// NotificationSettings holds a user's digest schedule and email language
+type NotificationSettings struct {
+	ID                 uint       `json:"id" gorm:"primaryKey"`
+	UserUID            string     `json:"userUid" gorm:"uniqueIndex;not null"`
+	Timezone           string     `json:"timezone" gorm:"type:varchar(64);not null"`
+	Locale             string     `json:"locale" gorm:"type:varchar(10)"`  // Language of emails; empty means the default locale
+	DigestHour         int        `json:"digestHour" gorm:"not null"`      // Local hour (0-23) digests are sent at
+	WeeklyDigestDay    int        `json:"weeklyDigestDay" gorm:"not null"` // 0 = Sunday ... 6 = Saturday
+	LastDailyDigestAt  *time.Time `json:"lastDailyDigestAt"`
+	LastWeeklyDigestAt *time.Time `json:"lastWeeklyDigestAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+}
+
+// TableName specifies the table name for NotificationSettings
+func (NotificationSettings) TableName() string {
+	return "notification_settings"
+}
+
+// NotificationDigestEntry is an email held back for a user's next daily or weekly digest
+type NotificationDigestEntry struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	RecipientUID string    `json:"recipientUid" gorm:"index;not null"`
+	EventType    string    `json:"eventType" gorm:"type:varchar(40);not null"`
+	Delivery     string    `json:"delivery" gorm:"type:varchar(10);index;not null"`
+	Subject      string    `json:"subject" gorm:"not null"`
+	Summary      string    `json:"summary" gorm:"type:text"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// TableName specifies the table name for NotificationDigestEntry
+func (NotificationDigestEntry) TableName() string {
+	return "notification_digest_entries"
+}
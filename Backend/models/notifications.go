@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+// Notification types
+const (
+	NotificationApplicationSubmitted = "application_submitted"
+	NotificationStatusChanged        = "application_status_changed"
+	NotificationInterviewScheduled   = "interview_scheduled"
+	NotificationFeedbackReceived     = "feedback_received"
+	NotificationWorkingUserRemoved   = "working_user_removed"
+	NotificationDirectMessage        = "direct_message" // Email only; messages have their own unread state
+	NotificationQuestionAsked        = "question_asked"
+	NotificationQuestionAnswered     = "question_answered"
+	NotificationAnnouncement         = "project_announcement"
+	NotificationTaskAssigned         = "task_assigned"
+	NotificationTaskComment          = "task_comment"
+	NotificationHoursReviewed        = "hours_reviewed"
+	NotificationProjectCompleted     = "project_completed"
+)
+
+// Notification is an in-app notification shown in a user's notification center
+type Notification struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	RecipientUID  string     `json:"recipientUid" gorm:"index:idx_notifications_recipient_created,priority:1;not null"`
+	Type          string     `json:"type" gorm:"type:varchar(40);index;not null"`
+	Title         string     `json:"title" gorm:"not null"`
+	Body          string     `json:"body" gorm:"type:text"`
+	PID           string     `json:"pid,omitempty" gorm:"column:p_id;index"`
+	ApplicationID *uint      `json:"applicationId,omitempty" gorm:"index"`
+	ReadAt        *time.Time `json:"readAt" gorm:"index"`
+	CreatedAt     time.Time  `json:"createdAt" gorm:"index:idx_notifications_recipient_created,priority:2"`
+}
+
+// TableName specifies the table name for Notification
+func (Notification) TableName() string {
+	return "notifications"
+}
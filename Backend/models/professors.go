@@ -6,7 +6,17 @@ import (
 	"gorm.io/gorm"
 )
 
-type Professors struct {
+// AvailabilityWindow is a block of time a professor publishes for applicants to book interview slots in
+type AvailabilityWindow struct {
 	gorm.Model
-	Availability []time.Time `json:"availability"`
+	ProfessorUID string    `json:"professorUid" gorm:"index;not null"`
+	StartsAt     time.Time `json:"startsAt" gorm:"index;not null"`
+	EndsAt       time.Time `json:"endsAt" gorm:"not null"`
+	SlotMinutes  int       `json:"slotMinutes" gorm:"not null;default:30"`
+	Timezone     string    `json:"timezone" gorm:"type:varchar(64);not null"` // IANA zone the window was published in
+}
+
+// TableName specifies the table name for AvailabilityWindow
+func (AvailabilityWindow) TableName() string {
+	return "availability_windows"
 }
@@ -20,6 +20,16 @@ type ProjRequests struct {
 	InterviewDate    string    `json:"interviewDate" gorm:"type:varchar(100)"`
 	InterviewTime    string    `json:"interviewTime" gorm:"type:varchar(100)"`
 	InterviewDetails string    `json:"interviewDetails" gorm:"type:text"`
+
+	// Set when the application moves to a final status; cleared if it is reopened
+	DecidedAt *time.Time `json:"decidedAt" gorm:"index"`
+
+	// Booked interview slot; InterviewDate/InterviewTime are kept filled for older clients
+	InterviewStartsAt    *time.Time `json:"interviewStartsAt" gorm:"index"`
+	InterviewEndsAt      *time.Time `json:"interviewEndsAt"`
+	InterviewTimezone    string     `json:"interviewTimezone" gorm:"type:varchar(64)"`
+	InterviewBookedAt    *time.Time `json:"interviewBookedAt"`
+	InterviewCancelledAt *time.Time `json:"interviewCancelledAt"`
 }
 
 // TableName specifies the table name for ProjRequests
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// ProjectAnnouncement is a message a project's owners post to its working users
+type ProjectAnnouncement struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	PID        string    `json:"pid" gorm:"column:p_id;index:idx_project_announcements_project_created,priority:1;not null"`
+	AuthorUID  string    `json:"authorUid" gorm:"not null"`
+	Title      string    `json:"title" gorm:"not null"`
+	Body       string    `json:"body" gorm:"type:text;not null"`
+	RequireAck bool      `json:"requireAck" gorm:"not null"` // Working users are asked to confirm they have read it
+	CreatedAt  time.Time `json:"createdAt" gorm:"index:idx_project_announcements_project_created,priority:2"`
+}
+
+// TableName specifies the table name for ProjectAnnouncement
+func (ProjectAnnouncement) TableName() string {
+	return "project_announcements"
+}
+
+// AnnouncementAck records that a working user acknowledged an announcement
+type AnnouncementAck struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	AnnouncementID uint      `json:"announcementId" gorm:"uniqueIndex:idx_announcement_ack_user;not null"`
+	UserUID        string    `json:"userUid" gorm:"uniqueIndex:idx_announcement_ack_user;not null"`
+	AckedAt        time.Time `json:"ackedAt" gorm:"not null"`
+}
+
+// TableName specifies the table name for AnnouncementAck
+func (AnnouncementAck) TableName() string {
+	return "announcement_acks"
+}
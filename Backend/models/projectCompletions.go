@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// ProjectCompletion records that faculty closed a student's work on a project, with their evaluation.
+// Its certificate code is public so anyone holding the certificate can verify it.
+type ProjectCompletion struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	PID             string     `json:"pid" gorm:"column:p_id;uniqueIndex:idx_completion_project_user;not null"`
+	UID             string     `json:"uid" gorm:"uniqueIndex:idx_completion_project_user;index;not null"`
+	Role            string     `json:"role"` // Printed on the certificate, e.g. "Research Intern"
+	Rating          int        `json:"rating" gorm:"not null;check:rating BETWEEN 1 AND 5"`
+	Evaluation      string     `json:"evaluation" gorm:"type:text;not null"`
+	Hours           float64    `json:"hours" gorm:"type:numeric(7,2);not null"` // Approved logged hours at completion
+	CertificateCode string     `json:"certificateCode" gorm:"uniqueIndex;not null"`
+	CompletedBy     string     `json:"completedBy" gorm:"not null"`
+	CompletedAt     time.Time  `json:"completedAt" gorm:"not null"`
+	RevokedAt       *time.Time `json:"revokedAt"` // A revoked certificate no longer verifies
+	RevokeReason    string     `json:"revokeReason"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// TableName specifies the table name for ProjectCompletion
+func (ProjectCompletion) TableName() string {
+	return "project_completions"
+}
+
+// ProjectReview is a student's review of a project they completed
+type ProjectReview struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	CompletionID uint      `json:"completionId" gorm:"uniqueIndex;not null"`
+	PID          string    `json:"pid" gorm:"column:p_id;index;not null"`
+	UID          string    `json:"uid" gorm:"not null"`
+	Rating       int       `json:"rating" gorm:"not null;check:rating BETWEEN 1 AND 5"`
+	Comment      string    `json:"comment" gorm:"type:text"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// TableName specifies the table name for ProjectReview
+func (ProjectReview) TableName() string {
+	return "project_reviews"
+}
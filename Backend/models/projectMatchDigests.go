@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// ProjectMatchSubscription is a student's opt-in to the weekly email of new projects matching their profile
+type ProjectMatchSubscription struct {
+	ID               uint      `json:"id" gorm:"primaryKey"`
+	UserUID          string    `json:"userUid" gorm:"uniqueIndex;not null"`
+	Enabled          bool      `json:"enabled" gorm:"not null"`
+	UnsubscribeToken string    `json:"-" gorm:"uniqueIndex;not null"`
+	LastSentAt       time.Time `json:"lastSentAt" gorm:"not null"` // End of the window covered by the last digest; starts at opt-in
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// TableName specifies the table name for ProjectMatchSubscription
+func (ProjectMatchSubscription) TableName() string {
+	return "project_match_subscriptions"
+}
+
+// ProjectMatchSent records a project emailed to a student so it is never sent to them again
+type ProjectMatchSent struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	UserUID    string    `json:"userUid" gorm:"uniqueIndex:idx_project_match_sent_user_project;not null"`
+	PID        string    `json:"pid" gorm:"column:p_id;uniqueIndex:idx_project_match_sent_user_project;not null"`
+	MatchScore float64   `json:"matchScore"`
+	SentAt     time.Time `json:"sentAt" gorm:"not null"`
+}
+
+// TableName specifies the table name for ProjectMatchSent
+func (ProjectMatchSent) TableName() string {
+	return "project_match_sent"
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+)
+
+// ProjectQuestion is a question a student asked on a project's public Q&A board.
+// The author is never shown, so students can ask without being identified to the project owners.
+type ProjectQuestion struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	PID       string     `json:"pid" gorm:"column:p_id;index;not null"`
+	AuthorUID string     `json:"-" gorm:"index;not null"`
+	Body      string     `json:"body" gorm:"type:text;not null"`
+	Upvotes   int        `json:"upvotes" gorm:"not null"`
+	Hidden    bool       `json:"hidden" gorm:"not null"` // Hidden by a project owner as abusive
+	HiddenAt  *time.Time `json:"hiddenAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// TableName specifies the table name for ProjectQuestion
+func (ProjectQuestion) TableName() string {
+	return "project_questions"
+}
+
+// ProjectAnswer is a project owner's answer to a ProjectQuestion
+type ProjectAnswer struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	QuestionID uint       `json:"questionId" gorm:"index;not null"`
+	PID        string     `json:"pid" gorm:"column:p_id;index;not null"`
+	AuthorUID  string     `json:"authorUid" gorm:"not null"`
+	Body       string     `json:"body" gorm:"type:text;not null"`
+	Pinned     bool       `json:"pinned" gorm:"not null"`
+	Hidden     bool       `json:"hidden" gorm:"not null"`
+	HiddenAt   *time.Time `json:"hiddenAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// TableName specifies the table name for ProjectAnswer
+func (ProjectAnswer) TableName() string {
+	return "project_answers"
+}
+
+// ProjectQuestionVote records one user's upvote of a question; ProjectQuestion.Upvotes counts them
+type ProjectQuestionVote struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	QuestionID uint      `json:"questionId" gorm:"uniqueIndex:idx_project_question_vote;not null"`
+	UserUID    string    `json:"userUid" gorm:"uniqueIndex:idx_project_question_vote;not null"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// TableName specifies the table name for ProjectQuestionVote
+func (ProjectQuestionVote) TableName() string {
+	return "project_question_votes"
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+)
+
+// Task statuses
+const (
+	TaskTodo       = "todo"
+	TaskInProgress = "in_progress"
+	TaskBlocked    = "blocked"
+	TaskDone       = "done"
+)
+
+// ProjectMilestone groups a project's tasks towards a dated goal
+type ProjectMilestone struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	PID         string     `json:"pid" gorm:"column:p_id;index;not null"`
+	Title       string     `json:"title" gorm:"not null"`
+	Description string     `json:"description" gorm:"type:text"`
+	DueDate     *time.Time `json:"dueDate" gorm:"type:date"`
+	CreatedBy   string     `json:"createdBy" gorm:"not null"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// TableName specifies the table name for ProjectMilestone
+func (ProjectMilestone) TableName() string {
+	return "project_milestones"
+}
+
+// ProjectTask is a piece of work on a project, optionally under a milestone and assigned to a working user
+type ProjectTask struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	PID         string     `json:"pid" gorm:"column:p_id;index;not null"`
+	MilestoneID *uint      `json:"milestoneId" gorm:"index"`
+	Title       string     `json:"title" gorm:"not null"`
+	Description string     `json:"description" gorm:"type:text"`
+	AssigneeUID string     `json:"assigneeUid" gorm:"index"` // Empty while unassigned
+	Status      string     `json:"status" gorm:"type:varchar(20);index;not null;check:status IN ('todo','in_progress','blocked','done')"`
+	DueDate     *time.Time `json:"dueDate" gorm:"type:date;index"`
+	CompletedAt *time.Time `json:"completedAt"` // Set when the task moves to done; cleared if it is reopened
+	CreatedBy   string     `json:"createdBy" gorm:"not null"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// TableName specifies the table name for ProjectTask
+func (ProjectTask) TableName() string {
+	return "project_tasks"
+}
+
+// TaskComment is a comment on a ProjectTask by a project member
+type TaskComment struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TaskID    uint      `json:"taskId" gorm:"index;not null"`
+	AuthorUID string    `json:"authorUid" gorm:"not null"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TableName specifies the table name for TaskComment
+func (TaskComment) TableName() string {
+	return "task_comments"
+}
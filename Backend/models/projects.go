@@ -20,6 +20,14 @@ type Projects struct {
 	Duration       string         `json:"duration" gorm:"column:duration"`
 	PositionType   pq.StringArray `json:"positionType" gorm:"column:position_type;type:text[]"`
 	Deadline       *string        `json:"deadline" gorm:"column:deadline"`
+	BlindReview    bool           `json:"blindReview" gorm:"column:blind_review;default:false"` // Hide applicant identities until interview
+	Reviewers      pq.StringArray `json:"reviewers" gorm:"column:reviewers;type:text[]"`        // Faculty UIDs who can review applications alongside the creator
+
+	// Eligibility rules checked when a student applies
+	MinYear                 int            `json:"minYear" gorm:"column:min_year;default:0"`
+	AllowedDegrees          pq.StringArray `json:"allowedDegrees" gorm:"column:allowed_degrees;type:text[]"`
+	RequiredSkills          pq.StringArray `json:"requiredSkills" gorm:"column:required_skills;type:text[]"`
+	RequiredProfileSections pq.StringArray `json:"requiredProfileSections" gorm:"column:required_profile_sections;type:text[]"`
 }
 
 // TableName specifies the table name for Projects
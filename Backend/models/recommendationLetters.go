@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Recommendation request statuses
+const (
+	RecommendationPending   = "pending"
+	RecommendationSubmitted = "submitted"
+	RecommendationDeclined  = "declined"
+	RecommendationCancelled = "cancelled"
+)
+
+// RecommendationRequest asks a faculty member to write a letter for one application.
+// The letter itself is never serialised; only the target project's reviewers can read it through a dedicated view.
+type RecommendationRequest struct {
+	gorm.Model
+	ApplicationID       uint       `json:"applicationId" gorm:"uniqueIndex:idx_recommendation_application_referee;not null"`
+	PID                 string     `json:"pid" gorm:"column:p_id;index;not null"`
+	StudentUID          string     `json:"studentUid" gorm:"index;not null"`
+	RefereeUID          string     `json:"refereeUid" gorm:"uniqueIndex:idx_recommendation_application_referee;index;not null"`
+	Note                string     `json:"note" gorm:"type:text"` // Message from the student to the referee
+	Status              string     `json:"status" gorm:"type:varchar(20);default:'pending';check:status IN ('pending','submitted','declined','cancelled')"`
+	Token               string     `json:"-" gorm:"uniqueIndex;not null"` // Authenticates the referee's upload link
+	TokenExpiresAt      *time.Time `json:"-"`                             // Every email to the referee extends it
+	Letter              string     `json:"-" gorm:"type:text"`
+	LetterLink          string     `json:"-"`
+	RespondedAt         *time.Time `json:"respondedAt"`                          // When the letter was submitted or the request declined
+	ReminderCount       int        `json:"reminderCount" gorm:"default:0"`       // Automatic reminders
+	ManualReminderCount int        `json:"manualReminderCount" gorm:"default:0"` // Reminders the student sent
+	LastRemindedAt      *time.Time `json:"lastRemindedAt"`
+}
+
+// TableName specifies the table name for RecommendationRequest
+func (RecommendationRequest) TableName() string {
+	return "recommendation_requests"
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// Time entry review statuses
+const (
+	TimeEntryPending  = "pending"
+	TimeEntryApproved = "approved"
+	TimeEntryRejected = "rejected"
+)
+
+// TimeEntry is hours a working user logged against a project on one day
+type TimeEntry struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	PID         string     `json:"pid" gorm:"column:p_id;index:idx_time_entries_project_user,priority:1;not null"`
+	UID         string     `json:"uid" gorm:"index:idx_time_entries_project_user,priority:2;not null"`
+	WorkDate    time.Time  `json:"workDate" gorm:"type:date;index;not null"`
+	WeekStart   time.Time  `json:"weekStart" gorm:"type:date;index;not null"` // Monday of the week WorkDate falls in
+	Hours       float64    `json:"hours" gorm:"type:numeric(5,2);not null;check:hours > 0 AND hours <= 24"`
+	Description string     `json:"description" gorm:"type:text;not null"`
+	Status      string     `json:"status" gorm:"type:varchar(20);index;not null;check:status IN ('pending','approved','rejected')"`
+	ReviewedBy  string     `json:"reviewedBy"`
+	ReviewedAt  *time.Time `json:"reviewedAt"`
+	ReviewNote  string     `json:"reviewNote" gorm:"type:text"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// TableName specifies the table name for TimeEntry
+func (TimeEntry) TableName() string {
+	return "time_entries"
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Webhook event types
+const (
+	WebhookApplicationCreated       = "application.created"
+	WebhookApplicationStatusChanged = "application.status_changed"
+	WebhookProjectCreated           = "project.created"
+	WebhookWorkingUserRemoved       = "working_user.removed"
+)
+
+// Webhook delivery states
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookSubscription posts matching events to a URL. With a PID it receives that project's events;
+// without one it receives every event that concerns its owner.
+type WebhookSubscription struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	OwnerUID  string         `json:"ownerUid" gorm:"index;not null"`
+	PID       string         `json:"pid,omitempty" gorm:"column:p_id;index"`
+	URL       string         `json:"url" gorm:"type:text;not null"`
+	Secret    string         `json:"-" gorm:"not null"` // HMAC key for the X-Webhook-Signature header
+	Events    pq.StringArray `json:"events" gorm:"type:text[];not null"`
+	Active    bool           `json:"active" gorm:"not null"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// TableName specifies the table name for WebhookSubscription
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// WebhookDelivery is one event sent, or to be sent, to one subscription; the log users replay from
+type WebhookDelivery struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	SubscriptionID uint       `json:"subscriptionId" gorm:"index:idx_webhook_deliveries_subscription_created,priority:1;not null"`
+	EventID        string     `json:"eventId" gorm:"type:varchar(64);index;not null"` // Same for every delivery and replay of one event
+	EventType      string     `json:"eventType" gorm:"type:varchar(40);not null"`
+	Payload        string     `json:"payload" gorm:"type:text;not null"`
+	Status         string     `json:"status" gorm:"type:varchar(10);index;not null;check:status IN ('pending','succeeded','failed')"`
+	Attempts       int        `json:"attempts" gorm:"not null"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt" gorm:"index"`
+	ResponseStatus int        `json:"responseStatus"`
+	LastError      string     `json:"lastError" gorm:"type:text"`
+	ReplayOf       *uint      `json:"replayOf,omitempty"`
+	DeliveredAt    *time.Time `json:"deliveredAt"`
+	CreatedAt      time.Time  `json:"createdAt" gorm:"index:idx_webhook_deliveries_subscription_created,priority:2"`
+}
+
+// TableName specifies the table name for WebhookDelivery
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
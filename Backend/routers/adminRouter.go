@@ -0,0 +1,21 @@
+package routers
+
+import (
+	"backend/handlers"
+	"backend/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterAdminRoutes(api *echo.Group) {
+	admin := api.Group("/admin")
+
+	// Admins are the accounts listed in ADMIN_EMAILS
+	admin.Use(middleware.JWTMiddleware())
+	admin.Use(middleware.RequireAdmin())
+
+	admin.GET("/email-templates", handlers.GetEmailTemplates)                   // List email templates and locales
+	admin.GET("/email-templates/:name/preview", handlers.PreviewEmailTemplate)  // Preview a template with sample data
+	admin.POST("/email-templates/:name/preview", handlers.PreviewEmailTemplate) // Preview a template with custom data
+	admin.GET("/timesheets", handlers.ExportTimesheets)                         // Export a month of approved hours across all projects as CSV or XLSX
+}
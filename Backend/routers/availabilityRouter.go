@@ -0,0 +1,20 @@
+package routers
+
+import (
+	"backend/handlers"
+	"backend/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterAvailabilityRoutes(api *echo.Group) {
+	availability := api.Group("/availability")
+
+	// Apply authentication middleware to all availability routes
+	availability.Use(middleware.JWTMiddleware())
+
+	// Interview availability routes (Faculty only)
+	availability.POST("", handlers.CreateAvailabilityWindow, middleware.RequireUserType("fac"))             // Publish an availability window
+	availability.GET("/my", handlers.GetMyAvailability, middleware.RequireUserType("fac"))                  // Get own upcoming windows and booked interviews
+	availability.DELETE("/:windowId", handlers.DeleteAvailabilityWindow, middleware.RequireUserType("fac")) // Delete an availability window
+}
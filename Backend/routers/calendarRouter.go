@@ -0,0 +1,18 @@
+package routers
+
+import (
+	"backend/handlers"
+	"backend/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterCalendarRoutes(api *echo.Group) {
+	calendar := api.Group("/calendar")
+
+	// Apply authentication middleware to all calendar routes
+	calendar.Use(middleware.JWTMiddleware())
+
+	calendar.GET("/feed", handlers.GetCalendarFeedURL)            // Get own calendar subscription URL
+	calendar.POST("/feed/rotate", handlers.RotateCalendarFeedURL) // Replace own calendar subscription URL
+}
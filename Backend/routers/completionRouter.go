@@ -0,0 +1,20 @@
+package routers
+
+import (
+	"backend/handlers"
+	"backend/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterCompletionRoutes(api *echo.Group) {
+	completions := api.Group("/completions")
+	completions.Use(middleware.JWTMiddleware())
+
+	completions.GET("/my", handlers.GetMyCompletions, middleware.RequireUserType("stu"))                         // Get own completed projects with evaluations
+	completions.PUT("/:completionId/review", handlers.ReviewCompletedProject, middleware.RequireUserType("stu")) // Review a completed project
+	completions.GET("/:completionId/certificate", handlers.DownloadCompletionCertificate)                        // Download the certificate PDF (Student or project owners)
+
+	// Certificate verification is public; the code printed on the certificate is the lookup key
+	api.GET("/certificates/:code", handlers.VerifyCertificate) // Verify a completion certificate
+}
@@ -0,0 +1,22 @@
+package routers
+
+import (
+	"backend/handlers"
+	"backend/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterConversationRoutes(api *echo.Group) {
+	conversations := api.Group("/conversations")
+
+	// Apply authentication middleware to all conversation routes
+	conversations.Use(middleware.JWTMiddleware())
+
+	conversations.GET("", handlers.GetMyConversations)                    // List own conversations with unread counts (paginated)
+	conversations.POST("", handlers.StartConversation)                    // Message a user, opening a conversation if needed
+	conversations.GET("/unread-count", handlers.GetUnreadMessageCount)    // Get the number of unread messages
+	conversations.GET("/:id/messages", handlers.GetConversationMessages)  // List messages and mark them read (paginated)
+	conversations.POST("/:id/messages", handlers.SendConversationMessage) // Send a message
+	conversations.PUT("/:id/read", handlers.MarkConversationRead)         // Mark the conversation as read
+}
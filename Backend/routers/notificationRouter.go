@@ -0,0 +1,22 @@
+package routers
+
+import (
+	"backend/handlers"
+	"backend/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterNotificationRoutes(api *echo.Group) {
+	notifications := api.Group("/notifications")
+
+	// Apply authentication middleware to all notification routes
+	notifications.Use(middleware.JWTMiddleware())
+
+	notifications.GET("", handlers.GetMyNotifications)                        // List own notifications (paginated)
+	notifications.GET("/unread-count", handlers.GetUnreadNotificationCount)   // Get the number of unread notifications
+	notifications.PUT("/read-all", handlers.MarkAllNotificationsRead)         // Mark every notification as read
+	notifications.GET("/preferences", handlers.GetNotificationPreferences)    // Get per-event channels, delivery and digest schedule
+	notifications.PUT("/preferences", handlers.UpdateNotificationPreferences) // Update per-event channels, delivery and digest schedule
+	notifications.PUT("/:id/read", handlers.MarkNotificationRead)             // Mark a notification as read
+}
@@ -0,0 +1,19 @@
+package routers
+
+import (
+	"backend/handlers"
+	"backend/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterProjectMatchRoutes(api *echo.Group) {
+	matches := api.Group("/project-matches")
+
+	// Weekly matches email subscription (Student only)
+	matches.GET("", handlers.GetProjectMatchSubscription, middleware.JWTMiddleware(), middleware.RequireUserType("stu"))    // Get my subscription
+	matches.PUT("", handlers.UpdateProjectMatchSubscription, middleware.JWTMiddleware(), middleware.RequireUserType("stu")) // Subscribe or unsubscribe
+
+	// Unsubscribe link from the email; the token in the URL authenticates the student
+	matches.POST("/unsubscribe/:token", handlers.UnsubscribeProjectMatches) // Unsubscribe without logging in
+}
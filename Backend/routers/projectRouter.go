@@ -28,16 +28,93 @@ func RegisterProjectRoutes(api *echo.Group) {
 	projects.POST("/:id/apply", handlers.ApplyToProject, middleware.RequireUserType("stu"))                                     // Apply to a project (Students only)
 	projects.DELETE("/:id/retract", handlers.RetractApplication, middleware.RequireUserType("stu"))                             // Retract application (Students only)
 	projects.GET("/:id/application-status", handlers.GetMyApplicationForProject, middleware.RequireUserType("stu"))             // Get student's application status for a specific project (Students only)
+	projects.GET("/:id/eligibility", handlers.GetProjectEligibility, middleware.RequireUserType("stu"))                         // Check eligibility rules for a project (Students only)
+	projects.GET("/:id/draft", handlers.GetApplicationDraft, middleware.RequireUserType("stu"))                                 // Get own draft application for a project (Students only)
+	projects.PUT("/:id/draft", handlers.SaveApplicationDraft, middleware.RequireUserType("stu"))                                // Save or autosave a draft application (Students only)
+	projects.DELETE("/:id/draft", handlers.DeleteApplicationDraft, middleware.RequireUserType("stu"))                           // Discard a draft application (Students only)
+	projects.POST("/:id/draft/submit", handlers.SubmitApplicationDraft, middleware.RequireUserType("stu"))                      // Submit a draft as an application (Students only)
 	projects.GET("/:id/applications", handlers.GetProjectApplications, middleware.RequireUserType("fac"))                       // Get all applications for a project (Faculty only)
+	projects.GET("/:id/applications/export", handlers.ExportProjectApplications, middleware.RequireUserType("fac"))             // Export applications of a project as CSV or XLSX (Faculty only)
+	projects.GET("/:id/analytics", handlers.GetProjectAnalytics, middleware.RequireUserType("fac"))                             // Get application funnel analytics for a project (Faculty only)
 	projects.GET("/:id/past-applicants", handlers.GetPastApplicantsForProject, middleware.RequireUserType("fac"))               // Get past applicants (accepted/rejected) for a project (Faculty only)
 	projects.PUT("/:id/applications/:appId", handlers.UpdateApplicationStatus, middleware.RequireUserType("fac"))               // Update application status (Faculty only)
 	projects.POST("/:id/applications/:appId/feedback", handlers.SendApplicationFeedback, middleware.RequireUserType("fac"))     // Send feedback to student (Faculty only)
 	projects.POST("/:id/applications/:appId/schedule-interview", handlers.ScheduleInterview, middleware.RequireUserType("fac")) // Schedule interview (Faculty only)
+	projects.GET("/:id/applications/:appId/messages", handlers.GetApplicationThread, middleware.RequireUserType("fac"))         // Get the feedback thread of an application (Faculty only)
+
+	// Private review routes (Project owners and reviewers only)
+	projects.GET("/:id/applications/:appId/review", handlers.GetApplicationReview, middleware.RequireUserType("fac"))                   // Get private notes and tags of an application
+	projects.POST("/:id/applications/:appId/notes", handlers.AddApplicationNote, middleware.RequireUserType("fac"))                     // Add a private note to an application
+	projects.DELETE("/:id/applications/:appId/notes/:noteId", handlers.DeleteApplicationNote, middleware.RequireUserType("fac"))        // Delete own private note
+	projects.POST("/:id/applications/:appId/tags", handlers.AddApplicationTag, middleware.RequireUserType("fac"))                       // Add a custom tag to an application
+	projects.DELETE("/:id/applications/:appId/tags/:label", handlers.RemoveApplicationTag, middleware.RequireUserType("fac"))           // Remove a custom tag from an application
+	projects.GET("/:id/applications/:appId/recommendations", handlers.GetApplicationRecommendations, middleware.RequireUserType("fac")) // Read submitted recommendation letters
+
+	// Q&A board routes; answering and moderation are for project owners and reviewers
+	projects.GET("/:id/questions", handlers.GetProjectQuestions)                                                                     // List the project's questions and answers (paginated)
+	projects.POST("/:id/questions", handlers.AskProjectQuestion, middleware.RequireUserType("stu"))                                  // Ask a question (Students only)
+	projects.POST("/:id/questions/:questionId/upvote", handlers.UpvoteProjectQuestion)                                               // Upvote a question
+	projects.DELETE("/:id/questions/:questionId/upvote", handlers.RemoveProjectQuestionUpvote)                                       // Remove own upvote
+	projects.PUT("/:id/questions/:questionId/hide", handlers.HideProjectQuestion, middleware.RequireUserType("fac"))                 // Hide or restore a question
+	projects.POST("/:id/questions/:questionId/answers", handlers.AnswerProjectQuestion, middleware.RequireUserType("fac"))           // Answer a question
+	projects.PUT("/:id/questions/:questionId/answers/:answerId/pin", handlers.PinProjectAnswer, middleware.RequireUserType("fac"))   // Pin or unpin an answer
+	projects.PUT("/:id/questions/:questionId/answers/:answerId/hide", handlers.HideProjectAnswer, middleware.RequireUserType("fac")) // Hide or restore an answer
+
+	// Announcement routes; the feed is for project members only
+	projects.GET("/:id/announcements", handlers.GetProjectAnnouncements)                                                         // List the project's announcements (paginated)
+	projects.POST("/:id/announcements", handlers.PostProjectAnnouncement, middleware.RequireUserType("fac"))                     // Post an announcement to the working users
+	projects.POST("/:id/announcements/:announcementId/ack", handlers.AcknowledgeAnnouncement, middleware.RequireUserType("stu")) // Acknowledge an announcement (Working users only)
+	projects.GET("/:id/announcements/:announcementId/acks", handlers.GetAnnouncementAcks, middleware.RequireUserType("fac"))     // See which working users acknowledged
+
+	// Milestone and task routes; planning is for project owners and reviewers, assignees may move their own tasks
+	projects.GET("/:id/milestones", handlers.GetProjectMilestones)                                                      // List milestones with task progress (Project members only)
+	projects.POST("/:id/milestones", handlers.CreateProjectMilestone, middleware.RequireUserType("fac"))                // Add a milestone
+	projects.PUT("/:id/milestones/:milestoneId", handlers.UpdateProjectMilestone, middleware.RequireUserType("fac"))    // Update a milestone
+	projects.DELETE("/:id/milestones/:milestoneId", handlers.DeleteProjectMilestone, middleware.RequireUserType("fac")) // Delete a milestone, keeping its tasks
+	projects.GET("/:id/tasks", handlers.GetProjectTasks)                                                                // List tasks, filtered by milestone, assignee or status (Project members only)
+	projects.POST("/:id/tasks", handlers.CreateProjectTask, middleware.RequireUserType("fac"))                          // Add a task
+	projects.PUT("/:id/tasks/:taskId", handlers.UpdateProjectTask)                                                      // Update a task (assignees may only change its status)
+	projects.DELETE("/:id/tasks/:taskId", handlers.DeleteProjectTask, middleware.RequireUserType("fac"))                // Delete a task and its comments
+	projects.GET("/:id/tasks/:taskId/comments", handlers.GetTaskComments)                                               // List a task's comments (Project members only)
+	projects.POST("/:id/tasks/:taskId/comments", handlers.AddTaskComment)                                               // Comment on a task (Project members only)
+	projects.GET("/:id/progress", handlers.GetProjectProgress)                                                          // Per-member task progress, members who are behind first
+
+	// Hours logging routes; working users log their own hours, project owners and reviewers approve them
+	projects.GET("/:id/hours", handlers.GetProjectHours)                                                   // List a month of logged hours with weekly totals (Project members only)
+	projects.POST("/:id/hours", handlers.LogProjectHours, middleware.RequireUserType("stu"))               // Log hours worked on a day (Working users only)
+	projects.PUT("/:id/hours/review", handlers.ReviewProjectHours, middleware.RequireUserType("fac"))      // Approve or reject a batch of entries
+	projects.PUT("/:id/hours/:entryId", handlers.UpdateProjectHours, middleware.RequireUserType("stu"))    // Correct own entry unless approved
+	projects.DELETE("/:id/hours/:entryId", handlers.DeleteProjectHours, middleware.RequireUserType("stu")) // Delete own entry unless approved
+	projects.GET("/:id/timesheet", handlers.ExportProjectTimesheet)                                        // Export a monthly timesheet as CSV or XLSX (Project members only)
+
+	// Completion routes (Project owners and reviewers only)
+	projects.GET("/:id/completions", handlers.GetProjectCompletions, middleware.RequireUserType("fac"))                         // List completions with the students' reviews
+	projects.POST("/:id/completions", handlers.CompleteProjectMember, middleware.RequireUserType("fac"))                        // Mark a working user as completed with an evaluation
+	projects.PUT("/:id/completions/:completionId", handlers.UpdateProjectCompletion, middleware.RequireUserType("fac"))         // Correct an evaluation or rating
+	projects.POST("/:id/completions/:completionId/revoke", handlers.RevokeProjectCompletion, middleware.RequireUserType("fac")) // Revoke a completion and its certificate
+
+	// Bulk application routes (Faculty only)
+	projects.PUT("/:id/applications/bulk/status", handlers.BulkUpdateApplicationStatus, middleware.RequireUserType("fac"))        // Update status of many applications
+	projects.POST("/:id/applications/bulk/feedback", handlers.BulkSendApplicationFeedback, middleware.RequireUserType("fac"))     // Send templated feedback to many applicants
+	projects.POST("/:id/applications/bulk/schedule-interview", handlers.BulkScheduleInterview, middleware.RequireUserType("fac")) // Schedule interviews for many applications
 
 	// Student application routes
 	applications := api.Group("/applications")
 	applications.Use(middleware.JWTMiddleware())
-	applications.GET("/my", handlers.GetMyApplications, middleware.RequireUserType("stu"))                     // Get student's own applications with full details
-	applications.GET("/my/applied-projects", handlers.GetMyAppliedProjects, middleware.RequireUserType("stu")) // Get lightweight list of applied project IDs and statuses
-	applications.GET("/all", handlers.GetAllMyProjectApplications, middleware.RequireUserType("fac"))          // Get all applications for all professor's projects
+	applications.GET("/my", handlers.GetMyApplications, middleware.RequireUserType("stu"))                                       // Get student's own applications with full details
+	applications.GET("/my/applied-projects", handlers.GetMyAppliedProjects, middleware.RequireUserType("stu"))                   // Get lightweight list of applied project IDs and statuses
+	applications.GET("/my/limits", handlers.GetMyApplicationLimits, middleware.RequireUserType("stu"))                           // Get usage against the application limits
+	applications.GET("/my/drafts", handlers.GetMyApplicationDrafts, middleware.RequireUserType("stu"))                           // Get student's saved draft applications
+	applications.GET("/all", handlers.GetAllMyProjectApplications, middleware.RequireUserType("fac"))                            // Get all applications for all professor's projects
+	applications.GET("/all/export", handlers.ExportAllMyProjectApplications, middleware.RequireUserType("fac"))                  // Export applications of all professor's projects as CSV or XLSX
+	applications.GET("/all/analytics", handlers.GetMyApplicationAnalytics, middleware.RequireUserType("fac"))                    // Get application funnel analytics across all professor's projects
+	applications.GET("/:appId/messages", handlers.GetMyApplicationThread, middleware.RequireUserType("stu"))                     // Get the feedback thread of own application
+	applications.POST("/:appId/messages", handlers.ReplyToApplicationThread, middleware.RequireUserType("stu"))                  // Reply to the feedback thread of own application
+	applications.GET("/:appId/interview-slots", handlers.GetInterviewSlots, middleware.RequireUserType("stu"))                   // List open interview slots for an invited application
+	applications.POST("/:appId/interview", handlers.BookInterviewSlot, middleware.RequireUserType("stu"))                        // Book or reschedule an interview slot
+	applications.DELETE("/:appId/interview", handlers.CancelInterviewBooking, middleware.RequireUserType("stu"))                 // Cancel a booked interview slot
+	applications.GET("/:appId/recommendations", handlers.GetMyRecommendationRequests, middleware.RequireUserType("stu"))         // Track recommendation requests of own application
+	applications.POST("/:appId/recommendations", handlers.RequestRecommendation, middleware.RequireUserType("stu"))              // Ask a faculty member for a recommendation letter
+	applications.POST("/:appId/recommendations/:recId/remind", handlers.RemindRecommendation, middleware.RequireUserType("stu")) // Remind a referee of a pending request
+	applications.DELETE("/:appId/recommendations/:recId", handlers.CancelRecommendation, middleware.RequireUserType("stu"))      // Cancel a pending recommendation request
 }
@@ -0,0 +1,20 @@
+package routers
+
+import (
+	"backend/handlers"
+	"backend/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterRecommendationRoutes(api *echo.Group) {
+	letters := api.Group("/recommendation-letters")
+
+	// Referee inbox (Faculty only)
+	letters.GET("/my", handlers.GetMyRefereeRequests, middleware.JWTMiddleware(), middleware.RequireUserType("fac")) // List recommendation requests addressed to me
+
+	// Upload link routes; the token in the URL authenticates the referee
+	letters.GET("/:token", handlers.GetRecommendationLetterRequest)        // View a recommendation request
+	letters.POST("/:token", handlers.SubmitRecommendationLetter)           // Submit a recommendation letter
+	letters.POST("/:token/decline", handlers.DeclineRecommendationRequest) // Decline a recommendation request
+}
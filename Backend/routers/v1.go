@@ -12,6 +12,12 @@ func SetupRoutes(e *echo.Echo) {
 	// Health route without CORS validation (should work without CORS headers)
 	e.GET("/health", handlers.Health)
 
+	// Calendar feeds are fetched by calendar clients that send no Origin header; the token in the URL authenticates
+	e.GET("/calendar/:token", handlers.GetCalendarFeed)
+
+	// One-click unsubscribe POSTs come from mail providers, which send no Origin header either
+	e.POST("/unsubscribe/project-matches/:token", handlers.UnsubscribeProjectMatches)
+
 	// Apply CORS validation middleware to all /v1 routes
 	api := e.Group("/v1", middleware.CORSValidator())
 
@@ -21,6 +27,34 @@ func SetupRoutes(e *echo.Echo) {
 	// Project routes
 	RegisterProjectRoutes(api)
 
+	// Interview availability routes
+	RegisterAvailabilityRoutes(api)
+
+	// Calendar routes
+	RegisterCalendarRoutes(api)
+
+	// Recommendation letter routes
+	RegisterRecommendationRoutes(api)
+
+	// Notification routes
+	RegisterNotificationRoutes(api)
+
+	// Weekly matching projects email routes
+	RegisterProjectMatchRoutes(api)
+
+	// Outgoing webhook routes
+	RegisterWebhookRoutes(api)
+
+	// Direct message routes
+	RegisterConversationRoutes(api)
+
+	// Project completion and certificate routes
+	RegisterCompletionRoutes(api)
+
+	// Real-time event stream (EventSource cannot send headers, so it connects with a short-lived ?token= from /events/token)
+	api.POST("/events/token", handlers.IssueStreamToken, middleware.JWTMiddleware())
+	api.GET("/events", handlers.StreamEvents, middleware.StreamTokenMiddleware())
+
 	// Profile routes
 	RegisterProfileRoutes(api)
 
@@ -29,4 +63,7 @@ func SetupRoutes(e *echo.Echo) {
 
 	// Roadmap routes
 	SetupRoadmapRoutes(api)
+
+	// Admin routes
+	RegisterAdminRoutes(api)
 }
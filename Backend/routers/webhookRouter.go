@@ -0,0 +1,24 @@
+package routers
+
+import (
+	"backend/handlers"
+	"backend/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterWebhookRoutes(api *echo.Group) {
+	webhooks := api.Group("/webhooks")
+
+	// Apply authentication middleware to all webhook routes; webhooks report on owned projects (Faculty only)
+	webhooks.Use(middleware.JWTMiddleware())
+	webhooks.Use(middleware.RequireUserType("fac"))
+
+	webhooks.GET("", handlers.GetMyWebhooks)                                            // List own webhooks and the available event types
+	webhooks.POST("", handlers.CreateWebhook)                                           // Subscribe a URL to events (returns the signing secret)
+	webhooks.PUT("/:id", handlers.UpdateWebhook)                                        // Change URL, events or active flag
+	webhooks.DELETE("/:id", handlers.DeleteWebhook)                                     // Delete a webhook and its delivery log
+	webhooks.POST("/:id/rotate-secret", handlers.RotateWebhookSecret)                   // Replace the signing secret
+	webhooks.GET("/:id/deliveries", handlers.GetWebhookDeliveries)                      // Delivery log (paginated)
+	webhooks.POST("/:id/deliveries/:deliveryId/replay", handlers.ReplayWebhookDelivery) // Send a logged event again
+}
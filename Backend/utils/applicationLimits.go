@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// ApplicationLimits caps how many open applications a student may hold; zero disables a limit
+type ApplicationLimits struct {
+	MaxOpen      int           // Open applications at any time
+	MaxPerWindow int           // Open applications submitted within Window
+	Window       time.Duration // Rolling window for MaxPerWindow
+}
+
+// LoadApplicationLimits loads application limits from environment variables
+func LoadApplicationLimits() ApplicationLimits {
+	maxOpen, _ := strconv.Atoi(os.Getenv("APPLICATION_LIMIT_OPEN"))
+	maxPerWindow, _ := strconv.Atoi(os.Getenv("APPLICATION_LIMIT_WINDOW"))
+	windowDays, _ := strconv.Atoi(os.Getenv("APPLICATION_LIMIT_WINDOW_DAYS"))
+	if windowDays <= 0 {
+		windowDays = 30
+	}
+
+	return ApplicationLimits{
+		MaxOpen:      maxOpen,
+		MaxPerWindow: maxPerWindow,
+		Window:       time.Duration(windowDays) * 24 * time.Hour,
+	}
+}
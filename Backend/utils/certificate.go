@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// CompletionCertificate is what a project completion certificate states
+type CompletionCertificate struct {
+	StudentName    string
+	ProjectName    string
+	SupervisorName string
+	Role           string
+	Hours          float64 // Approved logged hours; left off the certificate when zero
+	CompletedAt    time.Time
+	Code           string
+	VerifyURL      string
+}
+
+// BuildCompletionCertificate lays out a certificate as an A4 landscape PDF
+func BuildCompletionCertificate(cert CompletionCertificate) []byte {
+	doc := NewPDFDocument(842, 595, fmt.Sprintf("Certificate of Completion - %s", cert.StudentName))
+
+	// Double border
+	doc.SetColor(0, 0, 0)
+	doc.Rect(24, 24, 794, 547, 3)
+	doc.Rect(34, 34, 774, 527, 0.75)
+
+	doc.CenteredText(480, 34, PDFHelveticaBold, "CERTIFICATE OF COMPLETION")
+	doc.SetColor(0.4, 0.4, 0.4)
+	doc.CenteredText(440, 14, PDFHelvetica, "This certifies that")
+
+	doc.SetColor(0, 0, 0)
+	doc.CenteredText(395, 30, PDFHelveticaBold, cert.StudentName)
+	doc.Line(221, 383, 621, 383, 0.75)
+
+	doc.SetColor(0.4, 0.4, 0.4)
+	participation := "has successfully completed their work on the research project"
+	if cert.Role != "" {
+		participation = fmt.Sprintf("has successfully completed their work as %s on the research project", cert.Role)
+	}
+	y := 350.0
+	for _, line := range PDFWrapText(participation, 14, PDFHelvetica, 680) {
+		doc.CenteredText(y, 14, PDFHelvetica, line)
+		y -= 20
+	}
+
+	doc.SetColor(0, 0, 0)
+	y -= 12
+	for _, line := range PDFWrapText(cert.ProjectName, 22, PDFHelveticaBold, 680) {
+		doc.CenteredText(y, 22, PDFHelveticaBold, line)
+		y -= 28
+	}
+
+	doc.SetColor(0.4, 0.4, 0.4)
+	details := fmt.Sprintf("Supervised by %s", cert.SupervisorName)
+	if cert.Hours > 0 {
+		details += fmt.Sprintf(" - %s approved hours", strconv.FormatFloat(cert.Hours, 'f', -1, 64))
+	}
+	doc.CenteredText(y-6, 13, PDFHelvetica, details)
+
+	// Completion date and signature line
+	doc.SetColor(0, 0, 0)
+	doc.Line(120, 150, 340, 150, 0.75)
+	doc.Text(120, 132, 11, PDFHelvetica, "Date of completion")
+	doc.Text(120, 158, 13, PDFHelveticaBold, cert.CompletedAt.Format("January 2, 2006"))
+	doc.Line(502, 150, 722, 150, 0.75)
+	doc.Text(502, 132, 11, PDFHelvetica, "Supervisor")
+	doc.Text(502, 158, 13, PDFHelveticaBold, cert.SupervisorName)
+
+	// Verification footer
+	doc.SetColor(0.4, 0.4, 0.4)
+	doc.CenteredText(78, 10, PDFHelvetica, fmt.Sprintf("Verification code: %s", cert.Code))
+	doc.CenteredText(62, 10, PDFHelveticaOblique, fmt.Sprintf("Verify this certificate at %s", cert.VerifyURL))
+
+	return doc.Bytes()
+}
@@ -1,9 +1,14 @@
 package utils
 
 import (
+	"bytes"
 	"crypto/tls"
+	"encoding/base64"
 	"fmt"
+	"mime"
+	"mime/multipart"
 	"net/smtp"
+	"net/textproto"
 	"os"
 	"strconv"
 	"strings"
@@ -22,10 +27,20 @@ type EmailConfig struct {
 
 // EmailMessage represents an email to be sent
 type EmailMessage struct {
-	To      []string
-	Subject string
-	Body    string
-	IsHTML  bool
+	To          []string
+	Subject     string
+	Body        string
+	TextBody    string // Plain-text alternative to an HTML Body; sent as multipart/alternative when set
+	IsHTML      bool
+	Attachments []EmailAttachment
+	Headers     map[string]string // Extra headers, such as List-Unsubscribe
+}
+
+// EmailAttachment is a file sent alongside an email body
+type EmailAttachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
 }
 
 // LoadEmailConfig loads email configuration from environment variables
@@ -54,23 +69,44 @@ func SendEmail(config *EmailConfig, message *EmailMessage) error {
 		return fmt.Errorf("no recipients specified")
 	}
 
+	msg := buildEmailMessage(config, message)
+
+	// Determine authentication method
+	auth := smtp.PlainAuth("", config.SMTPUsername, config.SMTPPassword, config.SMTPHost)
+	addr := fmt.Sprintf("%s:%d", config.SMTPHost, config.SMTPPort)
+
+	// Send email based on TLS configuration
+	if config.UseTLS {
+		return sendEmailTLS(addr, auth, config.FromEmail, message.To, msg)
+	}
+
+	return smtp.SendMail(addr, auth, config.FromEmail, message.To, msg)
+}
+
+// buildEmailMessage renders the headers and body of a message into its wire format
+func buildEmailMessage(config *EmailConfig, message *EmailMessage) []byte {
 	// Build the email headers and body
 	from := config.FromEmail
 	if config.FromName != "" {
 		from = fmt.Sprintf("%s <%s>", config.FromName, config.FromEmail)
 	}
 
-	contentType := "text/plain; charset=UTF-8"
-	if message.IsHTML {
-		contentType = "text/html; charset=UTF-8"
-	}
+	contentType, body := buildBodyPart(message)
 
 	headers := make(map[string]string)
 	headers["From"] = from
 	headers["To"] = strings.Join(message.To, ", ")
-	headers["Subject"] = message.Subject
+	headers["Subject"] = mime.QEncoding.Encode("UTF-8", message.Subject)
 	headers["MIME-Version"] = "1.0"
 	headers["Content-Type"] = contentType
+	for key, value := range message.Headers {
+		headers[key] = value
+	}
+
+	// Messages with attachments are wrapped in a multipart/mixed envelope
+	if len(message.Attachments) > 0 {
+		headers["Content-Type"], body = buildMultipartBody(contentType, body, message.Attachments)
+	}
 
 	// Build the message
 	var emailBody strings.Builder
@@ -78,18 +114,159 @@ func SendEmail(config *EmailConfig, message *EmailMessage) error {
 		emailBody.WriteString(fmt.Sprintf("%s: %s\r\n", key, value))
 	}
 	emailBody.WriteString("\r\n")
-	emailBody.WriteString(message.Body)
+	emailBody.WriteString(body)
+
+	return []byte(emailBody.String())
+}
+
+// buildBodyPart returns the content type and content of the message body, pairing an HTML body with its
+// plain-text alternative as multipart/alternative when one is set
+func buildBodyPart(message *EmailMessage) (string, string) {
+	if !message.IsHTML {
+		return "text/plain; charset=UTF-8", message.Body
+	}
+	if message.TextBody == "" {
+		return "text/html; charset=UTF-8", message.Body
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	// Clients show the last part they support, so the HTML part goes after the text part
+	for _, alternative := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", message.TextBody},
+		{"text/html; charset=UTF-8", message.Body},
+	} {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", alternative.contentType)
+		header.Set("Content-Transfer-Encoding", "8bit")
+		if part, err := writer.CreatePart(header); err == nil {
+			part.Write([]byte(alternative.body))
+		}
+	}
+	writer.Close()
+
+	return "multipart/alternative; boundary=" + writer.Boundary(), buf.String()
+}
+
+// buildMultipartBody renders the body and attachments as multipart/mixed and returns the envelope content type
+func buildMultipartBody(bodyContentType, body string, attachments []EmailAttachment) (string, string) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	bodyHeader := textproto.MIMEHeader{}
+	bodyHeader.Set("Content-Type", bodyContentType)
+	bodyHeader.Set("Content-Transfer-Encoding", "8bit")
+	if part, err := writer.CreatePart(bodyHeader); err == nil {
+		part.Write([]byte(body))
+	}
+
+	for _, attachment := range attachments {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", attachment.ContentType)
+		header.Set("Content-Transfer-Encoding", "base64")
+		header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.Filename))
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			continue
+		}
+		encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+		for len(encoded) > 76 {
+			part.Write([]byte(encoded[:76] + "\r\n"))
+			encoded = encoded[76:]
+		}
+		part.Write([]byte(encoded + "\r\n"))
+	}
+	writer.Close()
+
+	return "multipart/mixed; boundary=" + writer.Boundary(), buf.String()
+}
+
+// SendBatchEmails sends several emails over a single SMTP connection
+// Returns one error per message (nil on success) so callers can report per-recipient failures
+func SendBatchEmails(config *EmailConfig, messages []*EmailMessage) []error {
+	errs := make([]error, len(messages))
+	if len(messages) == 0 {
+		return errs
+	}
+
+	fail := func(err error) []error {
+		for i := range errs {
+			if errs[i] == nil {
+				errs[i] = err
+			}
+		}
+		return errs
+	}
+
+	if config.SMTPHost == "" || config.SMTPPort == 0 {
+		return fail(fmt.Errorf("SMTP configuration is incomplete"))
+	}
 
-	// Determine authentication method
-	auth := smtp.PlainAuth("", config.SMTPUsername, config.SMTPPassword, config.SMTPHost)
 	addr := fmt.Sprintf("%s:%d", config.SMTPHost, config.SMTPPort)
+	client, err := smtp.Dial(addr)
+	if err != nil {
+		return fail(fmt.Errorf("failed to connect to SMTP server: %w", err))
+	}
+	defer client.Close()
 
-	// Send email based on TLS configuration
-	if config.UseTLS {
-		return sendEmailTLS(addr, auth, config.FromEmail, message.To, []byte(emailBody.String()))
+	// Upgrade to TLS when required or when the server offers it (matches smtp.SendMail behaviour)
+	if ok, _ := client.Extension("STARTTLS"); ok || config.UseTLS {
+		if err := client.StartTLS(&tls.Config{ServerName: config.SMTPHost}); err != nil {
+			return fail(fmt.Errorf("failed to start TLS: %w", err))
+		}
+	}
+
+	if ok, _ := client.Extension("AUTH"); ok {
+		auth := smtp.PlainAuth("", config.SMTPUsername, config.SMTPPassword, config.SMTPHost)
+		if err := client.Auth(auth); err != nil {
+			return fail(fmt.Errorf("authentication failed: %w", err))
+		}
+	}
+
+	for i, message := range messages {
+		if len(message.To) == 0 {
+			errs[i] = fmt.Errorf("no recipients specified")
+			continue
+		}
+		if err := sendOnClient(client, config.FromEmail, message.To, buildEmailMessage(config, message)); err != nil {
+			errs[i] = err
+			// Clear the failed transaction so the next message can be sent
+			client.Reset()
+		}
+	}
+
+	client.Quit()
+	return errs
+}
+
+// sendOnClient sends a single message over an already established SMTP session
+func sendOnClient(client *smtp.Client, from string, to []string, msg []byte) error {
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("failed to set recipient %s: %w", recipient, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send DATA command: %w", err)
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
 	}
 
-	return smtp.SendMail(addr, auth, config.FromEmail, message.To, []byte(emailBody.String()))
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to close message writer: %w", err)
+	}
+
+	return nil
 }
 
 // sendEmailTLS sends email using explicit TLS connection (STARTTLS)
@@ -150,158 +327,52 @@ func sendEmailTLS(addr string, auth smtp.Auth, from string, to []string, msg []b
 	return client.Quit()
 }
 
-// SendVerificationEmail sends an email verification email
-func SendVerificationEmail(config *EmailConfig, toEmail, verificationToken string) error {
-	verificationURL := fmt.Sprintf("%s/verify-email?token=%s", os.Getenv("FRONTEND_URL"), verificationToken)
-
-	body := fmt.Sprintf(`
-		<html>
-		<body>
-			<h2>Email Verification</h2>
-			<p>Thank you for registering! Please verify your email address by clicking the link below:</p>
-			<p><a href="%s">Verify Email</a></p>
-			<p>Or copy and paste this link in your browser:</p>
-			<p>%s</p>
-			<p>This link will expire in 24 hours.</p>
-			<p>If you didn't create an account, please ignore this email.</p>
-		</body>
-		</html>
-	`, verificationURL, verificationURL)
-
-	message := &EmailMessage{
-		To:      []string{toEmail},
-		Subject: "Verify Your Email Address",
-		Body:    body,
-		IsHTML:  true,
+// SendVerificationEmail sends an email verification link
+func SendVerificationEmail(config *EmailConfig, toEmail, locale, verificationToken string) error {
+	message, err := NewTemplateEmail(toEmail, "verify_email_link", locale, map[string]interface{}{
+		"VerificationURL": fmt.Sprintf("%s/verify-email?token=%s", os.Getenv("FRONTEND_URL"), verificationToken),
+	})
+	if err != nil {
+		return err
 	}
-
 	return SendEmail(config, message)
 }
 
 // SendPasswordResetEmail sends a password reset email
-func SendPasswordResetEmail(config *EmailConfig, toEmail, resetToken string) error {
-	resetURL := fmt.Sprintf("%s/reset-password?token=%s", os.Getenv("FRONTEND_URL"), resetToken)
-
-	body := fmt.Sprintf(`
-		<html>
-		<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-			<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-				<!-- Header -->
-				<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-					<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-				</div>
-				
-				<!-- Content -->
-				<div style="padding: 40px 20px;">
-					<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">Password Reset Request</h2>
-					<p style="margin: 0 0 16px 0; color: #000;">You requested to reset your password. Click the link below to reset it:</p>
-					<div style="margin: 32px 0; text-align: center;">
-						<a href="%s" style="display: inline-block; background-color: #000; color: #fff; padding: 14px 32px; text-decoration: none; font-weight: 500; border: 1px solid #000;">Reset Password</a>
-					</div>
-					<p style="margin: 0 0 8px 0; color: #666; font-size: 14px;">Or copy and paste this link in your browser:</p>
-					<p style="margin: 0 0 16px 0; color: #666; font-size: 12px; word-break: break-all;">%s</p>
-					<p style="margin: 0 0 8px 0; color: #666; font-size: 14px;">This link will expire in 1 hour.</p>
-					<p style="margin: 0; color: #666; font-size: 14px;">If you didn't request a password reset, please ignore this email.</p>
-				</div>
-				
-				<!-- Footer -->
-				<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-					<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-					<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-				</div>
-			</div>
-		</body>
-		</html>
-	`, resetURL, resetURL)
-
-	message := &EmailMessage{
-		To:      []string{toEmail},
-		Subject: "Password Reset Request",
-		Body:    body,
-		IsHTML:  true,
+func SendPasswordResetEmail(config *EmailConfig, toEmail, locale, resetToken string) error {
+	message, err := NewTemplateEmail(toEmail, "password_reset", locale, map[string]interface{}{
+		"ResetURL": fmt.Sprintf("%s/reset-password?token=%s", os.Getenv("FRONTEND_URL"), resetToken),
+	})
+	if err != nil {
+		return err
 	}
-
 	return SendEmail(config, message)
 }
 
 // SendProjectApplicationEmail sends a notification email when someone applies to a project
-func SendProjectApplicationEmail(config *EmailConfig, toEmail, projectTitle, applicantName string) error {
-	body := fmt.Sprintf(`
-		<html>
-		<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-			<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-				<!-- Header -->
-				<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-					<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-				</div>
-				
-				<!-- Content -->
-				<div style="padding: 40px 20px;">
-					<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">New Project Application</h2>
-					<p style="margin: 0 0 16px 0; color: #000;">You have received a new application for your project:</p>
-					<div style="background-color: #f5f5f5; padding: 20px; margin: 24px 0; border: 1px solid #000;">
-						<p style="margin: 0 0 8px 0; color: #000; font-weight: 600;">Project: %s</p>
-						<p style="margin: 0; color: #000;">Applicant: %s</p>
-					</div>
-					<p style="margin: 0; color: #000;">Please log in to your dashboard to review the application.</p>
-				</div>
-				
-				<!-- Footer -->
-				<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-					<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-					<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-				</div>
-			</div>
-		</body>
-		</html>
-	`, projectTitle, applicantName)
-
-	message := &EmailMessage{
-		To:      []string{toEmail},
-		Subject: fmt.Sprintf("New Application for %s", projectTitle),
-		Body:    body,
-		IsHTML:  true,
+func SendProjectApplicationEmail(config *EmailConfig, toEmail, locale, projectTitle, applicantName string) error {
+	message, err := BuildProjectApplicationEmail(toEmail, locale, projectTitle, applicantName)
+	if err != nil {
+		return err
 	}
-
 	return SendEmail(config, message)
 }
 
+// BuildProjectApplicationEmail builds the email telling a professor about a new application
+func BuildProjectApplicationEmail(toEmail, locale, projectTitle, applicantName string) (*EmailMessage, error) {
+	return NewTemplateEmail(toEmail, "application_received", locale, map[string]interface{}{
+		"ProjectName":   projectTitle,
+		"ApplicantName": applicantName,
+	})
+}
+
 // SendWelcomeEmail sends a welcome email after successful registration
-func SendWelcomeEmail(config *EmailConfig, toEmail, name string) error {
-	body := fmt.Sprintf(`
-		<html>
-		<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif; line-height: 1.6; color: #000; margin: 0; padding: 0; background-color: #ffffff;">
-			<div style="max-width: 600px; margin: 0 auto; background-color: #ffffff;">
-				<!-- Header -->
-				<div style="background-color: #000; padding: 32px 20px; text-align: center; border-bottom: 1px solid #000;">
-					<h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #fff; letter-spacing: -0.5px;">Feels Like Summer</h1>
-				</div>
-				
-				<!-- Content -->
-				<div style="padding: 40px 20px;">
-					<h2 style="margin: 0 0 24px 0; font-size: 20px; font-weight: 600; color: #000;">Welcome</h2>
-					<p style="margin: 0 0 16px 0; color: #000;">Hi %s,</p>
-					<p style="margin: 0 0 16px 0; color: #000;">Thank you for joining our platform. We're excited to have you here.</p>
-					<p style="margin: 0 0 16px 0; color: #000;">Get started by exploring projects or creating your own.</p>
-					<p style="margin: 0; color: #000;">If you have any questions, feel free to reach out to our support team.</p>
-				</div>
-				
-				<!-- Footer -->
-				<div style="background-color: #000; padding: 24px 20px; text-align: center; border-top: 1px solid #000;">
-					<p style="margin: 0; font-size: 12px; color: #fff; letter-spacing: 0.5px;">FEELS LIKE SUMMER</p>
-					<p style="margin: 8px 0 0 0; font-size: 11px; color: #999;">Research opportunities that matter</p>
-				</div>
-			</div>
-		</body>
-		</html>
-	`, name)
-
-	message := &EmailMessage{
-		To:      []string{toEmail},
-		Subject: "Welcome to Feels Like Summer!",
-		Body:    body,
-		IsHTML:  true,
+func SendWelcomeEmail(config *EmailConfig, toEmail, locale, name string) error {
+	message, err := NewTemplateEmail(toEmail, "welcome", locale, map[string]interface{}{
+		"Name": name,
+	})
+	if err != nil {
+		return err
 	}
-
 	return SendEmail(config, message)
 }
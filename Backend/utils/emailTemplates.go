@@ -0,0 +1,185 @@
+package utils
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+)
+
+// DefaultEmailLocale is used when a recipient has no locale or one without templates
+const DefaultEmailLocale = "en"
+
+// Email templates live in templates/email: layout.html and layout.txt are shared by every email, and each
+// locale directory holds <name>.html (the HTML "content" block) and <name>.txt (the "subject" and plain-text
+// "content" blocks). A locale may leave out any email; the default locale's variant is used instead.
+//
+//go:embed templates/email
+var emailTemplateFS embed.FS
+
+// emailTaglines is the footer tagline of the shared layout in each locale
+var emailTaglines = map[string]string{
+	"en": "Research opportunities that matter",
+	"es": "Oportunidades de investigación que importan",
+}
+
+// emailTemplate is one email parsed for one locale
+type emailTemplate struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// emailTemplates maps locale, then email name, to its parsed templates
+var emailTemplates = mustLoadEmailTemplates()
+
+// EmailContent is an email rendered from its templates
+type EmailContent struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+}
+
+// mustLoadEmailTemplates parses every embedded email template; the templates ship with the binary, so a
+// parse error is a bug and stops the server at startup
+func mustLoadEmailTemplates() map[string]map[string]emailTemplate {
+	root := "templates/email"
+	layoutHTML := mustReadEmailTemplate(path.Join(root, "layout.html"))
+	layoutText := mustReadEmailTemplate(path.Join(root, "layout.txt"))
+
+	locales, err := fs.ReadDir(emailTemplateFS, root)
+	if err != nil {
+		panic(err)
+	}
+
+	templates := make(map[string]map[string]emailTemplate)
+	for _, locale := range locales {
+		if !locale.IsDir() {
+			continue
+		}
+
+		tagline := emailTaglines[locale.Name()]
+		if tagline == "" {
+			tagline = emailTaglines[DefaultEmailLocale]
+		}
+		funcs := map[string]interface{}{"tagline": func() string { return tagline }}
+
+		files, err := fs.Glob(emailTemplateFS, path.Join(root, locale.Name(), "*.html"))
+		if err != nil {
+			panic(err)
+		}
+
+		templates[locale.Name()] = make(map[string]emailTemplate)
+		for _, file := range files {
+			name := strings.TrimSuffix(path.Base(file), ".html")
+
+			html := htmltemplate.Must(htmltemplate.New(name).Funcs(funcs).Option("missingkey=error").Parse(layoutHTML))
+			htmltemplate.Must(html.Parse(mustReadEmailTemplate(file)))
+
+			text := texttemplate.Must(texttemplate.New(name).Funcs(funcs).Option("missingkey=error").Parse(layoutText))
+			texttemplate.Must(text.Parse(mustReadEmailTemplate(strings.TrimSuffix(file, ".html") + ".txt")))
+
+			templates[locale.Name()][name] = emailTemplate{html: html, text: text}
+		}
+	}
+
+	if len(templates[DefaultEmailLocale]) == 0 {
+		panic("no email templates found for the default locale")
+	}
+	return templates
+}
+
+// mustReadEmailTemplate reads one embedded template file
+func mustReadEmailTemplate(name string) string {
+	data, err := emailTemplateFS.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+// NormalizeEmailLocale maps a locale or Accept-Language value such as "es-MX,es;q=0.9" to a supported
+// email locale, falling back to DefaultEmailLocale
+func NormalizeEmailLocale(locale string) string {
+	for _, candidate := range strings.Split(locale, ",") {
+		candidate = strings.TrimSpace(strings.SplitN(candidate, ";", 2)[0])
+		candidate = strings.ToLower(strings.SplitN(strings.ReplaceAll(candidate, "_", "-"), "-", 2)[0])
+		if _, ok := emailTemplates[candidate]; ok {
+			return candidate
+		}
+	}
+	return DefaultEmailLocale
+}
+
+// IsSupportedEmailLocale reports whether emails can be rendered in the locale
+func IsSupportedEmailLocale(locale string) bool {
+	_, ok := emailTemplates[locale]
+	return ok
+}
+
+// EmailLocales returns the supported email locales
+func EmailLocales() []string {
+	locales := make([]string, 0, len(emailTemplates))
+	for locale := range emailTemplates {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// EmailTemplateNames returns the names of all email templates
+func EmailTemplateNames() []string {
+	names := make([]string, 0, len(emailTemplates[DefaultEmailLocale]))
+	for name := range emailTemplates[DefaultEmailLocale] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RenderEmail renders the subject, HTML and plain-text bodies of an email in the given locale.
+// Values in data are escaped in the HTML body, so user input such as feedback cannot inject markup.
+func RenderEmail(name, locale string, data interface{}) (*EmailContent, error) {
+	tmpl, ok := emailTemplates[NormalizeEmailLocale(locale)][name]
+	if !ok {
+		if tmpl, ok = emailTemplates[DefaultEmailLocale][name]; !ok {
+			return nil, fmt.Errorf("unknown email template %q", name)
+		}
+	}
+
+	var subject, html, text bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render subject of %s email: %w", name, err)
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render HTML body of %s email: %w", name, err)
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render text body of %s email: %w", name, err)
+	}
+
+	return &EmailContent{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		HTML:    html.String(),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+	}, nil
+}
+
+// NewTemplateEmail renders an email template into a message for one recipient
+func NewTemplateEmail(toEmail, name, locale string, data interface{}) (*EmailMessage, error) {
+	content, err := RenderEmail(name, locale, data)
+	if err != nil {
+		return nil, err
+	}
+	return &EmailMessage{
+		To:       []string{toEmail},
+		Subject:  content.Subject,
+		Body:     content.HTML,
+		TextBody: content.Text,
+		IsHTML:   true,
+	}, nil
+}
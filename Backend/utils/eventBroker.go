@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"log"
+	"sync"
+)
+
+// Event is a message pushed to a user's real-time stream
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// EventBroker delivers events to every open stream of a user.
+// The in-memory broker only reaches streams connected to this instance; a broker backed by
+// Postgres LISTEN/NOTIFY can implement the same interface to fan out across instances.
+type EventBroker interface {
+	// Publish sends an event to the user's streams without blocking
+	Publish(uid string, event Event)
+	// Subscribe opens a stream for the user; call the returned function to close it
+	Subscribe(uid string) (<-chan Event, func())
+	// Connected reports whether the user has an open stream, i.e. is online
+	Connected(uid string) bool
+}
+
+// eventBufferSize is how many events a slow stream may lag behind before events are dropped
+const eventBufferSize = 16
+
+// memoryBroker is an in-process EventBroker
+type memoryBroker struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan Event]struct{}
+}
+
+// NewMemoryBroker creates an in-process event broker
+func NewMemoryBroker() EventBroker {
+	return &memoryBroker{subscribers: make(map[string]map[chan Event]struct{})}
+}
+
+// Publish sends an event to the user's streams, dropping it for streams that are not keeping up
+func (b *memoryBroker) Publish(uid string, event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[uid] {
+		select {
+		case ch <- event:
+		default:
+			log.Printf("Dropping %s event for user %s: stream is full", event.Type, uid)
+		}
+	}
+}
+
+// Subscribe opens a stream for the user
+func (b *memoryBroker) Subscribe(uid string) (<-chan Event, func()) {
+	ch := make(chan Event, eventBufferSize)
+
+	b.mu.Lock()
+	if b.subscribers[uid] == nil {
+		b.subscribers[uid] = make(map[chan Event]struct{})
+	}
+	b.subscribers[uid][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[uid], ch)
+			if len(b.subscribers[uid]) == 0 {
+				delete(b.subscribers, uid)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
+
+// Connected reports whether the user has a stream open on this instance
+func (b *memoryBroker) Connected(uid string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers[uid]) > 0
+}
+
+var (
+	eventBroker     EventBroker = NewMemoryBroker()
+	eventBrokerLock sync.RWMutex
+)
+
+// GetEventBroker returns the broker used for real-time streams
+func GetEventBroker() EventBroker {
+	eventBrokerLock.RLock()
+	defer eventBrokerLock.RUnlock()
+	return eventBroker
+}
+
+// SetEventBroker replaces the broker, e.g. with one that spans multiple instances
+func SetEventBroker(broker EventBroker) {
+	eventBrokerLock.Lock()
+	defer eventBrokerLock.Unlock()
+	eventBroker = broker
+}
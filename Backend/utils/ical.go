@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Calendar methods used for iTIP messages and plain feeds (RFC 5546)
+const (
+	CalendarMethodPublish = "PUBLISH"
+	CalendarMethodRequest = "REQUEST"
+	CalendarMethodCancel  = "CANCEL"
+)
+
+const icalProdID = "-//Feels Like Summer//Calendar//EN"
+
+// CalendarEvent is a single VEVENT in an iCalendar document
+type CalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool // Start and End are treated as dates; End is exclusive
+	Sequence    int
+	Cancelled   bool
+	Organizer   string   // Email address
+	Attendees   []string // Email addresses
+}
+
+// BuildCalendar renders events into an RFC 5545 iCalendar document
+func BuildCalendar(method, name string, events []CalendarEvent) string {
+	var b strings.Builder
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:"+icalProdID)
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	if method != "" {
+		writeICalLine(&b, "METHOD:"+method)
+	}
+	if name != "" {
+		writeICalLine(&b, "X-WR-CALNAME:"+escapeICalText(name))
+	}
+
+	stamp := formatICalTime(time.Now())
+	for _, event := range events {
+		writeICalLine(&b, "BEGIN:VEVENT")
+		writeICalLine(&b, "UID:"+event.UID)
+		writeICalLine(&b, "DTSTAMP:"+stamp)
+		if event.AllDay {
+			writeICalLine(&b, "DTSTART;VALUE=DATE:"+event.Start.Format("20060102"))
+			writeICalLine(&b, "DTEND;VALUE=DATE:"+event.End.Format("20060102"))
+		} else {
+			writeICalLine(&b, "DTSTART:"+formatICalTime(event.Start))
+			writeICalLine(&b, "DTEND:"+formatICalTime(event.End))
+		}
+		writeICalLine(&b, "SUMMARY:"+escapeICalText(event.Summary))
+		if event.Description != "" {
+			writeICalLine(&b, "DESCRIPTION:"+escapeICalText(event.Description))
+		}
+		if event.Location != "" {
+			writeICalLine(&b, "LOCATION:"+escapeICalText(event.Location))
+		}
+		writeICalLine(&b, fmt.Sprintf("SEQUENCE:%d", event.Sequence))
+		if event.Organizer != "" {
+			writeICalLine(&b, "ORGANIZER:mailto:"+event.Organizer)
+		}
+		for _, attendee := range event.Attendees {
+			writeICalLine(&b, "ATTENDEE;ROLE=REQ-PARTICIPANT;RSVP=FALSE:mailto:"+attendee)
+		}
+		if event.Cancelled {
+			writeICalLine(&b, "STATUS:CANCELLED")
+		} else {
+			writeICalLine(&b, "STATUS:CONFIRMED")
+		}
+		writeICalLine(&b, "END:VEVENT")
+	}
+
+	writeICalLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// CalendarAttachment wraps an iCalendar document as an email attachment that mail clients treat as an invite
+func CalendarAttachment(method string, events []CalendarEvent) EmailAttachment {
+	return EmailAttachment{
+		Filename:    "invite.ics",
+		ContentType: fmt.Sprintf("text/calendar; charset=UTF-8; method=%s", method),
+		Data:        []byte(BuildCalendar(method, "", events)),
+	}
+}
+
+// formatICalTime formats a timestamp as a UTC DATE-TIME value
+func formatICalTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeICalText escapes a TEXT value as required by RFC 5545 section 3.3.11
+func escapeICalText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+// writeICalLine writes a content line folded at 75 octets without splitting UTF-8 sequences
+func writeICalLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // Continuation lines start with a space
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -16,9 +16,16 @@ type JWTClaims struct {
 	Email  string          `json:"email"`
 	Name   string          `json:"name"`
 	Type   models.UserType `json:"type"`
+	Scope  string          `json:"scope,omitempty"` // Empty for session tokens; set on narrowly scoped tokens
 	jwt.RegisteredClaims
 }
 
+// StreamTokenScope marks tokens that only open the real-time event stream
+const StreamTokenScope = "events"
+
+// StreamTokenTTL is how long a stream token can be used to connect; an open stream outlives it
+const StreamTokenTTL = 60 * time.Second
+
 // JWT secret key - in production, this should be loaded from environment variables
 var jwtSecret = []byte(getJWTSecret())
 
@@ -61,8 +68,55 @@ func GenerateJWT(user *models.User) (string, error) {
 	return tokenString, nil
 }
 
-// ValidateJWT validates a JWT token and returns the claims
+// ValidateJWT validates a session JWT and returns the claims; scoped tokens are rejected
 func ValidateJWT(tokenString string) (*JWTClaims, error) {
+	claims, err := parseJWT(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Scope != "" {
+		return nil, errors.New("token is scoped")
+	}
+	return claims, nil
+}
+
+// GenerateStreamToken creates a short-lived token that can only open the event stream, for clients
+// such as EventSource that have to pass it in the URL
+func GenerateStreamToken(claims *JWTClaims) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(StreamTokenTTL)
+	streamClaims := JWTClaims{
+		UserID: claims.UserID,
+		Email:  claims.Email,
+		Type:   claims.Type,
+		Scope:  StreamTokenScope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "feels-like-summer",
+			Subject:   claims.UserID,
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, streamClaims).SignedString(jwtSecret)
+	return token, expiresAt, err
+}
+
+// ValidateStreamToken validates a token issued by GenerateStreamToken and returns the claims
+func ValidateStreamToken(tokenString string) (*JWTClaims, error) {
+	claims, err := parseJWT(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Scope != StreamTokenScope {
+		return nil, errors.New("not a stream token")
+	}
+	return claims, nil
+}
+
+// parseJWT verifies a token's signature and expiry and returns its claims, whatever their scope
+func parseJWT(tokenString string) (*JWTClaims, error) {
 	// Parse token
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		// Validate signing method
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+)
+
+// MessagingRules are the anti-spam rules for direct messages; zero disables a limit
+type MessagingRules struct {
+	RequireApplication bool // Students and faculty may only start conversations over an application between them
+	MaxUnread          int  // Messages a sender may have waiting unread by the recipient
+	MaxPerHour         int  // Messages a user may send per hour
+}
+
+// LoadMessagingRules loads the direct message rules from environment variables
+func LoadMessagingRules() MessagingRules {
+	maxUnread := 5
+	if value, err := strconv.Atoi(os.Getenv("MESSAGING_MAX_UNREAD")); err == nil && value >= 0 {
+		maxUnread = value
+	}
+	maxPerHour := 30
+	if value, err := strconv.Atoi(os.Getenv("MESSAGING_MAX_PER_HOUR")); err == nil && value >= 0 {
+		maxPerHour = value
+	}
+
+	return MessagingRules{
+		RequireApplication: os.Getenv("MESSAGING_OPEN_CONTACT") != "true",
+		MaxUnread:          maxUnread,
+		MaxPerHour:         maxPerHour,
+	}
+}
@@ -0,0 +1,433 @@
+package utils
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf16"
+)
+
+// PDFFont is a font style. Text is set in the TrueType fonts configured through PDF_FONTS, embedded as subsets,
+// falling back per character to the standard Type 1 font of the style that every PDF reader ships with.
+type PDFFont int
+
+const (
+	PDFHelvetica PDFFont = iota
+	PDFHelveticaBold
+	PDFHelveticaOblique
+)
+
+// pdfFontNames maps each PDFFont to its base font name; the resource name is F1, F2, ...
+var pdfFontNames = []string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique"}
+
+// Glyph widths of printable ASCII (32-126) in thousandths of the font size, from the Adobe font metrics.
+// Helvetica-Oblique shares the Helvetica widths.
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// pdfTrueTypeFonts holds the TrueType fonts tried for each style, in fallback order. PDF_FONTS lists .ttf or .ttc
+// files, or directories of them, separated by commas; each font serves the style its head table declares, and
+// regular fonts also back up the bold and oblique styles.
+var pdfTrueTypeFonts = sync.OnceValue(func() [3][]*trueTypeFont {
+	var styles [3][]*trueTypeFont
+	var regular []*trueTypeFont
+	for _, path := range pdfFontPaths(os.Getenv("PDF_FONTS")) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("Failed to read PDF font %s: %v", path, err)
+			continue
+		}
+		font, err := parseTrueType(data)
+		if err != nil {
+			log.Printf("Skipping PDF font %s: %v", path, err)
+			continue
+		}
+
+		switch {
+		case font.bold && font.italic:
+			// No bold oblique style to serve
+		case font.bold:
+			styles[PDFHelveticaBold] = append(styles[PDFHelveticaBold], font)
+		case font.italic:
+			styles[PDFHelveticaOblique] = append(styles[PDFHelveticaOblique], font)
+		default:
+			regular = append(regular, font)
+		}
+	}
+
+	styles[PDFHelvetica] = regular
+	styles[PDFHelveticaBold] = append(styles[PDFHelveticaBold], regular...)
+	styles[PDFHelveticaOblique] = append(styles[PDFHelveticaOblique], regular...)
+	return styles
+})
+
+// pdfFontPaths expands the PDF_FONTS list, replacing each directory with the fonts inside it in name order
+func pdfFontPaths(list string) []string {
+	var paths []string
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		info, err := os.Stat(entry)
+		if err != nil || !info.IsDir() {
+			paths = append(paths, entry)
+			continue
+		}
+
+		var found []string
+		filepath.WalkDir(entry, func(path string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				if ext := strings.ToLower(filepath.Ext(path)); ext == ".ttf" || ext == ".ttc" {
+					found = append(found, path)
+				}
+			}
+			return nil
+		})
+		sort.Strings(found)
+		paths = append(paths, found...)
+	}
+	return paths
+}
+
+// pdfTextRun is a stretch of text set in one font; font is nil for the style's standard Type 1 font
+type pdfTextRun struct {
+	font  *trueTypeFont
+	runes []rune
+}
+
+// pdfTextRuns splits text by the first font of the style's fallback chain that has a glyph for each character
+func pdfTextRuns(text string, style PDFFont) []pdfTextRun {
+	fonts := pdfTrueTypeFonts()[style]
+
+	var runs []pdfTextRun
+	for _, r := range text {
+		if r < 32 {
+			r = ' '
+		}
+		var font *trueTypeFont
+		for _, candidate := range fonts {
+			if _, ok := candidate.cmap[r]; ok {
+				font = candidate
+				break
+			}
+		}
+		if len(runs) > 0 && runs[len(runs)-1].font == font {
+			runs[len(runs)-1].runes = append(runs[len(runs)-1].runes, r)
+		} else {
+			runs = append(runs, pdfTextRun{font: font, runes: []rune{r}})
+		}
+	}
+	return runs
+}
+
+// pdfEmbeddedFont is a TrueType font used by a document, with the glyphs it needs and the text they stand for
+type pdfEmbeddedFont struct {
+	font     *trueTypeFont
+	resource string
+	glyphs   map[uint16]rune
+}
+
+// PDFDocument builds a single-page PDF out of text, lines and rectangles.
+// Coordinates are in points from the bottom-left corner of the page.
+type PDFDocument struct {
+	width, height float64
+	title         string
+	content       strings.Builder
+	embedded      []*pdfEmbeddedFont
+}
+
+// NewPDFDocument starts a blank page of the given size in points (A4 landscape is 842 x 595)
+func NewPDFDocument(width, height float64, title string) *PDFDocument {
+	return &PDFDocument{width: width, height: height, title: title}
+}
+
+// Width is the page width in points
+func (d *PDFDocument) Width() float64 {
+	return d.width
+}
+
+// SetColor sets the fill and stroke color used by the following drawing operations; components range 0-1
+func (d *PDFDocument) SetColor(r, g, b float64) {
+	fmt.Fprintf(&d.content, "%.3f %.3f %.3f rg %.3f %.3f %.3f RG\n", r, g, b, r, g, b)
+}
+
+// Text draws a line of text with its baseline starting at x, y
+func (d *PDFDocument) Text(x, y, size float64, font PDFFont, text string) {
+	fmt.Fprintf(&d.content, "BT %.2f %.2f Td", x, y)
+	for _, run := range pdfTextRuns(text, font) {
+		if run.font == nil {
+			fmt.Fprintf(&d.content, " /F%d %.2f Tf (%s) Tj", int(font)+1, size, pdfEscape(string(run.runes)))
+			continue
+		}
+
+		// Embedded fonts are addressed by glyph ID (Identity-H)
+		embedded := d.embed(run.font)
+		glyphs := make([]byte, 0, 2*len(run.runes))
+		for _, r := range run.runes {
+			gid := run.font.cmap[r]
+			embedded.glyphs[gid] = r
+			glyphs = append(glyphs, byte(gid>>8), byte(gid))
+		}
+		fmt.Fprintf(&d.content, " /%s %.2f Tf <%s> Tj", embedded.resource, size, hex.EncodeToString(glyphs))
+	}
+	d.content.WriteString(" ET\n")
+}
+
+// embed registers a TrueType font with the document, returning its entry
+func (d *PDFDocument) embed(font *trueTypeFont) *pdfEmbeddedFont {
+	for _, embedded := range d.embedded {
+		if embedded.font == font {
+			return embedded
+		}
+	}
+	embedded := &pdfEmbeddedFont{font: font, resource: fmt.Sprintf("U%d", len(d.embedded)+1), glyphs: make(map[uint16]rune)}
+	d.embedded = append(d.embedded, embedded)
+	return embedded
+}
+
+// CenteredText draws a line of text centered horizontally on the page
+func (d *PDFDocument) CenteredText(y, size float64, font PDFFont, text string) {
+	d.Text((d.width-PDFTextWidth(text, size, font))/2, y, size, font, text)
+}
+
+// Line draws a straight line
+func (d *PDFDocument) Line(x1, y1, x2, y2, lineWidth float64) {
+	fmt.Fprintf(&d.content, "%.2f w %.2f %.2f m %.2f %.2f l S\n", lineWidth, x1, y1, x2, y2)
+}
+
+// Rect draws the outline of a rectangle whose bottom-left corner is at x, y
+func (d *PDFDocument) Rect(x, y, w, h, lineWidth float64) {
+	fmt.Fprintf(&d.content, "%.2f w %.2f %.2f %.2f %.2f re S\n", lineWidth, x, y, w, h)
+}
+
+// WriteTo writes the finished document to w
+func (d *PDFDocument) WriteTo(w io.Writer) (int64, error) {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+	}
+
+	// Each embedded font takes five objects after the standard fonts: Type0 font, CIDFont, descriptor,
+	// font file and ToUnicode map
+	fontRefs := make([]string, 0, len(pdfFontNames)+len(d.embedded))
+	for i := range pdfFontNames {
+		fontRefs = append(fontRefs, fmt.Sprintf("/F%d %d 0 R", i+1, 5+i))
+	}
+	firstEmbedded := 5 + len(pdfFontNames)
+	for i, embedded := range d.embedded {
+		fontRefs = append(fontRefs, fmt.Sprintf("/%s %d 0 R", embedded.resource, firstEmbedded+5*i))
+	}
+	objects = append(objects, fmt.Sprintf(
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << %s >> >> /Contents 4 0 R >>",
+		d.width, d.height, strings.Join(fontRefs, " "),
+	))
+
+	stream := d.content.String()
+	objects = append(objects, fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(stream), stream))
+
+	for _, name := range pdfFontNames {
+		objects = append(objects, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+	}
+	for i, embedded := range d.embedded {
+		objects = append(objects, embedded.objects(firstEmbedded+5*i)...)
+	}
+	objects = append(objects, fmt.Sprintf("<< /Title %s /CreationDate (D:%s) >>", pdfTextString(d.title), time.Now().UTC().Format("20060102150405Z")))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, len(objects), xref)
+
+	return buf.WriteTo(w)
+}
+
+// Bytes returns the finished document
+func (d *PDFDocument) Bytes() []byte {
+	var buf bytes.Buffer
+	d.WriteTo(&buf)
+	return buf.Bytes()
+}
+
+// objects renders the five PDF objects of an embedded font, numbered from first
+func (e *pdfEmbeddedFont) objects(first int) []string {
+	glyphs := make([]uint16, 0, len(e.glyphs))
+	for gid := range e.glyphs {
+		glyphs = append(glyphs, gid)
+	}
+	sort.Slice(glyphs, func(i, j int) bool { return glyphs[i] < glyphs[j] })
+
+	font := e.font
+	name := subsetTag(glyphs) + "+" + font.name
+
+	var widths strings.Builder
+	for _, gid := range glyphs {
+		fmt.Fprintf(&widths, "%d [%d] ", gid, font.advance(gid))
+	}
+
+	flags, italicAngle := 32, 0 // Nonsymbolic
+	if font.italic {
+		flags, italicAngle = flags|64, -12
+	}
+
+	var compressed bytes.Buffer
+	subset := font.subset(glyphs)
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(subset)
+	zw.Close()
+
+	// ToUnicode keeps the text searchable and copyable
+	var cmap strings.Builder
+	cmap.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	cmap.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	cmap.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	cmap.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	for start := 0; start < len(glyphs); start += 100 {
+		chunk := glyphs[start:min(start+100, len(glyphs))]
+		fmt.Fprintf(&cmap, "%d beginbfchar\n", len(chunk))
+		for _, gid := range chunk {
+			fmt.Fprintf(&cmap, "<%04X> <%s>\n", gid, pdfUTF16Hex(string(e.glyphs[gid])))
+		}
+		cmap.WriteString("endbfchar\n")
+	}
+	cmap.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	toUnicode := cmap.String()
+
+	return []string{
+		fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+			name, first+1, first+4),
+		fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /CIDToGIDMap /Identity /W [%s] >>",
+			name, first+2, strings.TrimSpace(widths.String())),
+		fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags %d /FontBBox [%d %d %d %d] /ItalicAngle %d /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+			name, flags, font.scaled(font.bbox[0]), font.scaled(font.bbox[1]), font.scaled(font.bbox[2]), font.scaled(font.bbox[3]),
+			italicAngle, font.scaled(font.ascent), font.scaled(font.descent), font.scaled(font.capHeight), first+3),
+		fmt.Sprintf("<< /Length %d /Length1 %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), len(subset), compressed.String()),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(toUnicode), toUnicode),
+	}
+}
+
+// PDFTextWidth measures text in points
+func PDFTextWidth(text string, size float64, font PDFFont) float64 {
+	widths := &helveticaWidths
+	if font == PDFHelveticaBold {
+		widths = &helveticaBoldWidths
+	}
+
+	total := 0
+	for _, run := range pdfTextRuns(text, font) {
+		if run.font != nil {
+			for _, r := range run.runes {
+				total += run.font.advance(run.font.cmap[r])
+			}
+			continue
+		}
+		for _, b := range pdfEncode(string(run.runes)) {
+			if b >= 32 && b <= 126 {
+				total += widths[b-32]
+			} else {
+				total += 556 // Close enough for accented Latin-1 letters
+			}
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// PDFWrapText splits text into lines that fit within maxWidth points
+func PDFWrapText(text string, size float64, font PDFFont, maxWidth float64) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if line != "" && PDFTextWidth(candidate, size, font) > maxWidth {
+			lines = append(lines, line)
+			candidate = word
+		}
+		line = candidate
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// pdfEncode converts text to WinAnsiEncoding bytes; characters outside Latin-1 become '?'
+func pdfEncode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r < 32:
+			encoded = append(encoded, ' ')
+		case r < 127 || (r >= 0xA0 && r <= 0xFF):
+			encoded = append(encoded, byte(r))
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+	return encoded
+}
+
+// pdfTextString encodes text for document metadata, as UTF-16 with a byte order mark so any script survives
+func pdfTextString(text string) string {
+	return "<FEFF" + pdfUTF16Hex(text) + ">"
+}
+
+// pdfUTF16Hex encodes text as big-endian UTF-16 in hexadecimal
+func pdfUTF16Hex(text string) string {
+	var b strings.Builder
+	for _, unit := range utf16.Encode([]rune(text)) {
+		fmt.Fprintf(&b, "%04X", unit)
+	}
+	return b.String()
+}
+
+// pdfEscape encodes text as the body of a PDF literal string
+func pdfEscape(text string) string {
+	var b strings.Builder
+	for _, c := range pdfEncode(text) {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
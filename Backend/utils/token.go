@@ -2,32 +2,54 @@ package utils
 
 import (
 	"crypto/rand"
+	"encoding/base32"
 	"encoding/hex"
 	"fmt"
 )
 
-// GenerateResetToken generates a secure random token for password reset
-func GenerateResetToken() (string, error) {
-	// Generate 32 random bytes (256 bits)
-	bytes := make([]byte, 32)
+// randomHexToken generates n secure random bytes (32 gives 256 bits) encoded as a hexadecimal string
+func randomHexToken(n int) (string, error) {
+	bytes := make([]byte, n)
 	if _, err := rand.Read(bytes); err != nil {
 		return "", fmt.Errorf("failed to generate random token: %w", err)
 	}
-
-	// Convert to hexadecimal string
 	return hex.EncodeToString(bytes), nil
 }
 
+// GenerateResetToken generates a secure random token for password reset
+func GenerateResetToken() (string, error) {
+	return randomHexToken(32)
+}
+
 // GenerateVerificationToken generates a secure random token for email verification
 func GenerateVerificationToken() (string, error) {
-	// Generate 32 random bytes (256 bits)
-	bytes := make([]byte, 32)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", fmt.Errorf("failed to generate random token: %w", err)
+	return randomHexToken(32)
+}
+
+// GenerateFeedToken generates a secure random token for subscribable calendar feed URLs
+func GenerateFeedToken() (string, error) {
+	return randomHexToken(32)
+}
+
+// GenerateRecommendationToken generates a secure random token for a referee's letter upload link
+func GenerateRecommendationToken() (string, error) {
+	return randomHexToken(32)
+}
+
+// GenerateUnsubscribeToken generates a secure random token for one-click email unsubscribe links
+func GenerateUnsubscribeToken() (string, error) {
+	return randomHexToken(32)
+}
+
+// GenerateWebhookSecret generates the secret a webhook subscription's payloads are signed with
+func GenerateWebhookSecret() (string, error) {
+	token, err := randomHexToken(32)
+	if err != nil {
+		return "", err
 	}
 
-	// Convert to hexadecimal string
-	return hex.EncodeToString(bytes), nil
+	// Prefix makes secrets recognisable in config files and secret scanners
+	return "whsec_" + token, nil
 }
 
 // GenerateVerificationCode generates a 6-digit verification code
@@ -47,3 +69,16 @@ func GenerateVerificationCode() (string, error) {
 
 	return fmt.Sprintf("%06d", code), nil
 }
+
+// GenerateCertificateCode generates the public verification code printed on a completion certificate,
+// formatted as four groups of four characters (e.g. ABCD-EFGH-2345-6789)
+func GenerateCertificateCode() (string, error) {
+	// 10 random bytes encode to exactly 16 base32 characters
+	bytes := make([]byte, 10)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate random code: %w", err)
+	}
+
+	encoded := base32.StdEncoding.EncodeToString(bytes)
+	return fmt.Sprintf("%s-%s-%s-%s", encoded[0:4], encoded[4:8], encoded[8:12], encoded[12:16]), nil
+}
@@ -0,0 +1,429 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// trueTypeFont is a TrueType font parsed far enough to measure text and embed a subset of it in a PDF
+type trueTypeFont struct {
+	name       string            // PostScript name
+	tables     map[string][]byte // Raw tables, copied out of the file or collection
+	unitsPerEm int
+	numGlyphs  int
+	bold       bool
+	italic     bool
+	ascent     int // The remaining metrics are in font units
+	descent    int
+	capHeight  int
+	bbox       [4]int
+	advances   []int
+	cmap       map[rune]uint16
+	longLoca   bool
+}
+
+// Composite glyph component flags
+const (
+	glyfArgsAreWords    = 0x0001
+	glyfHaveScale       = 0x0008
+	glyfMoreComponents  = 0x0020
+	glyfHaveXYScale     = 0x0040
+	glyfHaveTwoByTwo    = 0x0080
+	trueTypeChecksumMag = 0xB1B0AFBA
+)
+
+// trueTypeSubsetTables are the tables kept in an embedded subset; cmap and post are not needed by PDF readers
+var trueTypeSubsetTables = []string{"OS/2", "cvt ", "fpgm", "glyf", "head", "hhea", "hmtx", "loca", "maxp", "name", "prep"}
+
+// parseTrueType parses a .ttf file, or the first font of a .ttc collection. Fonts with CFF outlines are rejected.
+func parseTrueType(data []byte) (*trueTypeFont, error) {
+	offset := 0
+	if len(data) >= 16 && string(data[:4]) == "ttcf" {
+		if binary.BigEndian.Uint32(data[8:12]) == 0 {
+			return nil, errors.New("empty font collection")
+		}
+		offset = int(binary.BigEndian.Uint32(data[12:16]))
+	}
+	if offset+12 > len(data) {
+		return nil, errors.New("truncated font header")
+	}
+
+	font := &trueTypeFont{tables: make(map[string][]byte)}
+	numTables := int(binary.BigEndian.Uint16(data[offset+4:]))
+	for i := 0; i < numTables; i++ {
+		record := offset + 12 + 16*i
+		if record+16 > len(data) {
+			return nil, errors.New("truncated table directory")
+		}
+		tag := string(data[record : record+4])
+		start := int(binary.BigEndian.Uint32(data[record+8:]))
+		length := int(binary.BigEndian.Uint32(data[record+12:]))
+		if start < 0 || length < 0 || start+length > len(data) {
+			return nil, fmt.Errorf("table %q is out of bounds", tag)
+		}
+		font.tables[tag] = data[start : start+length]
+	}
+
+	for _, tag := range []string{"head", "hhea", "hmtx", "maxp", "cmap", "loca", "glyf"} {
+		if font.tables[tag] == nil {
+			return nil, fmt.Errorf("missing %q table; only TrueType outlines are supported", tag)
+		}
+	}
+
+	head := font.tables["head"]
+	hhea := font.tables["hhea"]
+	maxp := font.tables["maxp"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 {
+		return nil, errors.New("truncated font tables")
+	}
+
+	font.unitsPerEm = int(binary.BigEndian.Uint16(head[18:]))
+	if font.unitsPerEm == 0 {
+		return nil, errors.New("invalid unitsPerEm")
+	}
+	for i := range font.bbox {
+		font.bbox[i] = int(int16(binary.BigEndian.Uint16(head[36+2*i:])))
+	}
+	macStyle := binary.BigEndian.Uint16(head[44:])
+	font.bold, font.italic = macStyle&1 != 0, macStyle&2 != 0
+	font.longLoca = binary.BigEndian.Uint16(head[50:]) == 1
+	font.numGlyphs = int(binary.BigEndian.Uint16(maxp[4:]))
+
+	font.ascent = int(int16(binary.BigEndian.Uint16(hhea[4:])))
+	font.descent = int(int16(binary.BigEndian.Uint16(hhea[6:])))
+	font.capHeight = font.ascent
+	if os2 := font.tables["OS/2"]; len(os2) >= 90 && binary.BigEndian.Uint16(os2) >= 2 {
+		if capHeight := int(int16(binary.BigEndian.Uint16(os2[88:]))); capHeight > 0 {
+			font.capHeight = capHeight
+		}
+	}
+
+	// Glyphs past the last long metric share its advance
+	numHMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	hmtx := font.tables["hmtx"]
+	if numHMetrics == 0 || len(hmtx) < 4*numHMetrics {
+		return nil, errors.New("truncated hmtx table")
+	}
+	font.advances = make([]int, font.numGlyphs)
+	for gid := range font.advances {
+		font.advances[gid] = int(binary.BigEndian.Uint16(hmtx[4*min(gid, numHMetrics-1):]))
+	}
+
+	var err error
+	if font.cmap, err = parseTrueTypeCmap(font.tables["cmap"], font.numGlyphs); err != nil {
+		return nil, err
+	}
+	font.name = parseTrueTypeName(font.tables["name"])
+	return font, nil
+}
+
+// parseTrueTypeCmap reads the best Unicode subtable: full-repertoire format 12, falling back to BMP format 4
+func parseTrueTypeCmap(cmap []byte, numGlyphs int) (map[rune]uint16, error) {
+	if len(cmap) < 4 {
+		return nil, errors.New("truncated cmap table")
+	}
+
+	format4, format12 := -1, -1
+	for i := 0; i < int(binary.BigEndian.Uint16(cmap[2:])); i++ {
+		record := 4 + 8*i
+		if record+8 > len(cmap) {
+			break
+		}
+		platform := binary.BigEndian.Uint16(cmap[record:])
+		encoding := binary.BigEndian.Uint16(cmap[record+2:])
+		offset := int(binary.BigEndian.Uint32(cmap[record+4:]))
+		if offset+2 > len(cmap) || !(platform == 0 || (platform == 3 && (encoding == 1 || encoding == 10))) {
+			continue
+		}
+		switch binary.BigEndian.Uint16(cmap[offset:]) {
+		case 4:
+			if format4 < 0 {
+				format4 = offset
+			}
+		case 12:
+			if format12 < 0 {
+				format12 = offset
+			}
+		}
+	}
+
+	glyphs := make(map[rune]uint16)
+	add := func(r rune, gid int) {
+		if gid > 0 && gid < numGlyphs {
+			glyphs[r] = uint16(gid)
+		}
+	}
+
+	switch {
+	case format12 >= 0 && format12+16 <= len(cmap):
+		groups := int(binary.BigEndian.Uint32(cmap[format12+12:]))
+		for i := 0; i < groups; i++ {
+			group := format12 + 16 + 12*i
+			if group+12 > len(cmap) {
+				break
+			}
+			start := rune(binary.BigEndian.Uint32(cmap[group:]))
+			end := rune(binary.BigEndian.Uint32(cmap[group+4:]))
+			startGID := int(binary.BigEndian.Uint32(cmap[group+8:]))
+			for r := start; r <= end && r <= 0x10FFFF; r++ {
+				add(r, startGID+int(r-start))
+			}
+		}
+	case format4 >= 0 && format4+14 <= len(cmap):
+		segCount := int(binary.BigEndian.Uint16(cmap[format4+6:])) / 2
+		endCodes := format4 + 14
+		startCodes := endCodes + 2*segCount + 2
+		deltas := startCodes + 2*segCount
+		rangeOffsets := deltas + 2*segCount
+		if rangeOffsets+2*segCount > len(cmap) {
+			return nil, errors.New("truncated cmap subtable")
+		}
+		for seg := 0; seg < segCount; seg++ {
+			end := int(binary.BigEndian.Uint16(cmap[endCodes+2*seg:]))
+			start := int(binary.BigEndian.Uint16(cmap[startCodes+2*seg:]))
+			delta := int(binary.BigEndian.Uint16(cmap[deltas+2*seg:]))
+			rangeOffset := int(binary.BigEndian.Uint16(cmap[rangeOffsets+2*seg:]))
+			for c := start; c <= end && c != 0xFFFF; c++ {
+				if rangeOffset == 0 {
+					add(rune(c), (c+delta)&0xFFFF)
+					continue
+				}
+				addr := rangeOffsets + 2*seg + rangeOffset + 2*(c-start)
+				if addr+2 > len(cmap) {
+					break
+				}
+				if gid := int(binary.BigEndian.Uint16(cmap[addr:])); gid != 0 {
+					add(rune(c), (gid+delta)&0xFFFF)
+				}
+			}
+		}
+	default:
+		return nil, errors.New("no Unicode cmap subtable")
+	}
+	return glyphs, nil
+}
+
+// parseTrueTypeName returns the font's PostScript name, or "Font" when it has none
+func parseTrueTypeName(name []byte) string {
+	if len(name) < 6 {
+		return "Font"
+	}
+	count := int(binary.BigEndian.Uint16(name[2:]))
+	storage := int(binary.BigEndian.Uint16(name[4:]))
+	for i := 0; i < count; i++ {
+		record := 6 + 12*i
+		if record+12 > len(name) {
+			break
+		}
+		platform := binary.BigEndian.Uint16(name[record:])
+		nameID := binary.BigEndian.Uint16(name[record+6:])
+		length := int(binary.BigEndian.Uint16(name[record+8:]))
+		offset := storage + int(binary.BigEndian.Uint16(name[record+10:]))
+		if nameID != 6 || offset+length > len(name) {
+			continue
+		}
+
+		raw := name[offset : offset+length]
+		var value string
+		if platform == 3 || platform == 0 {
+			units := make([]uint16, len(raw)/2)
+			for j := range units {
+				units[j] = binary.BigEndian.Uint16(raw[2*j:])
+			}
+			value = string(utf16.Decode(units))
+		} else {
+			value = string(raw)
+		}
+
+		// PDF names must stay plain ASCII without delimiters
+		value = strings.Map(func(r rune) rune {
+			if r > ' ' && r < 0x7F && !strings.ContainsRune("()<>[]{}/%#", r) {
+				return r
+			}
+			return -1
+		}, value)
+		if value != "" {
+			return value
+		}
+	}
+	return "Font"
+}
+
+// glyphData returns the outline of a glyph, empty for glyphs without contours
+func (f *trueTypeFont) glyphData(gid uint16) []byte {
+	loca, glyf := f.tables["loca"], f.tables["glyf"]
+	var start, end int
+	if f.longLoca {
+		if 4*int(gid)+8 > len(loca) {
+			return nil
+		}
+		start = int(binary.BigEndian.Uint32(loca[4*int(gid):]))
+		end = int(binary.BigEndian.Uint32(loca[4*int(gid)+4:]))
+	} else {
+		if 2*int(gid)+4 > len(loca) {
+			return nil
+		}
+		start = 2 * int(binary.BigEndian.Uint16(loca[2*int(gid):]))
+		end = 2 * int(binary.BigEndian.Uint16(loca[2*int(gid)+2:]))
+	}
+	if start >= end || end > len(glyf) {
+		return nil
+	}
+	return glyf[start:end]
+}
+
+// glyphComponents lists the glyphs a composite glyph is built from
+func (f *trueTypeFont) glyphComponents(gid uint16) []uint16 {
+	data := f.glyphData(gid)
+	if len(data) < 10 || int16(binary.BigEndian.Uint16(data)) >= 0 {
+		return nil
+	}
+
+	var components []uint16
+	for pos := 10; pos+4 <= len(data); {
+		flags := binary.BigEndian.Uint16(data[pos:])
+		components = append(components, binary.BigEndian.Uint16(data[pos+2:]))
+		pos += 4
+		if flags&glyfArgsAreWords != 0 {
+			pos += 4
+		} else {
+			pos += 2
+		}
+		switch {
+		case flags&glyfHaveScale != 0:
+			pos += 2
+		case flags&glyfHaveXYScale != 0:
+			pos += 4
+		case flags&glyfHaveTwoByTwo != 0:
+			pos += 8
+		}
+		if flags&glyfMoreComponents == 0 {
+			break
+		}
+	}
+	return components
+}
+
+// advance returns a glyph's advance width in thousandths of the font size
+func (f *trueTypeFont) advance(gid uint16) int {
+	if int(gid) >= len(f.advances) {
+		return 0
+	}
+	return f.advances[gid] * 1000 / f.unitsPerEm
+}
+
+// scaled converts font units to thousandths of the font size, as PDF font dictionaries expect
+func (f *trueTypeFont) scaled(units int) int {
+	return units * 1000 / f.unitsPerEm
+}
+
+// subsetTag derives the six-letter prefix that marks an embedded font as a subset
+func subsetTag(glyphs []uint16) string {
+	h := sha1.New()
+	for _, gid := range glyphs {
+		binary.Write(h, binary.BigEndian, gid)
+	}
+	sum := h.Sum(nil)
+	tag := make([]byte, 6)
+	for i := range tag {
+		tag[i] = 'A' + sum[i]%26
+	}
+	return string(tag)
+}
+
+// subset builds a standalone TrueType font keeping only the outlines of the given glyphs (plus .notdef and
+// composite components). Glyph IDs are unchanged, so the PDF can map CIDs to glyphs one to one.
+func (f *trueTypeFont) subset(glyphs []uint16) []byte {
+	keep := make(map[uint16]bool)
+	pending := append([]uint16{0}, glyphs...)
+	for len(pending) > 0 {
+		gid := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if keep[gid] || int(gid) >= f.numGlyphs {
+			continue
+		}
+		keep[gid] = true
+		pending = append(pending, f.glyphComponents(gid)...)
+	}
+
+	// Rebuild glyf and a long-format loca without the dropped outlines
+	var glyf bytes.Buffer
+	loca := make([]byte, 4*(f.numGlyphs+1))
+	for gid := 0; gid < f.numGlyphs; gid++ {
+		binary.BigEndian.PutUint32(loca[4*gid:], uint32(glyf.Len()))
+		if keep[uint16(gid)] {
+			glyf.Write(f.glyphData(uint16(gid)))
+			for glyf.Len()%4 != 0 {
+				glyf.WriteByte(0)
+			}
+		}
+	}
+	binary.BigEndian.PutUint32(loca[4*f.numGlyphs:], uint32(glyf.Len()))
+
+	head := append([]byte{}, f.tables["head"]...)
+	binary.BigEndian.PutUint32(head[8:], 0)  // checkSumAdjustment, filled in below
+	binary.BigEndian.PutUint16(head[50:], 1) // indexToLocFormat: long
+
+	tables := make(map[string][]byte)
+	for _, tag := range trueTypeSubsetTables {
+		if data, ok := f.tables[tag]; ok {
+			tables[tag] = data
+		}
+	}
+	tables["glyf"], tables["loca"], tables["head"] = glyf.Bytes(), loca, head
+
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	// Offset table
+	var out bytes.Buffer
+	searchRange, entrySelector := 1, 0
+	for searchRange*2 <= len(tags) {
+		searchRange *= 2
+		entrySelector++
+	}
+	binary.Write(&out, binary.BigEndian, []uint16{1, 0, uint16(len(tags)), uint16(16 * searchRange), uint16(entrySelector), uint16(16 * (len(tags) - searchRange))})
+
+	offset := 12 + 16*len(tags)
+	for _, tag := range tags {
+		data := tables[tag]
+		out.WriteString(tag)
+		binary.Write(&out, binary.BigEndian, []uint32{trueTypeChecksum(data), uint32(offset), uint32(len(data))})
+		offset += (len(data) + 3) &^ 3
+	}
+
+	headOffset := 0
+	for _, tag := range tags {
+		if tag == "head" {
+			headOffset = out.Len()
+		}
+		out.Write(tables[tag])
+		for out.Len()%4 != 0 {
+			out.WriteByte(0)
+		}
+	}
+
+	font := out.Bytes()
+	binary.BigEndian.PutUint32(font[headOffset+8:], trueTypeChecksumMag-trueTypeChecksum(font))
+	return font
+}
+
+// trueTypeChecksum sums data as big-endian 32-bit words, zero-padding the last one
+func trueTypeChecksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
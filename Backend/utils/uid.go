@@ -21,3 +21,12 @@ func GeneratePSID() (string, error) {
 	}
 	return "ps_" + hex.EncodeToString(bytes), nil
 }
+
+// GenerateEventID generates a webhook event ID with "evt_" prefix
+func GenerateEventID() (string, error) {
+	bytes := make([]byte, 12) // 12 bytes = 24 hex characters
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return "evt_" + hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// ErrWebhookAddressBlocked is returned when a webhook host resolves to an internal address
+var ErrWebhookAddressBlocked = errors.New("webhook host resolves to a private or reserved address")
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which net.IP has no helper for
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// webhookDialer refuses to connect to internal addresses. The check runs on the resolved address of every
+// connection, so a hostname that is re-pointed after validation (DNS rebinding) cannot reach them either.
+var webhookDialer = &net.Dialer{
+	Timeout: 5 * time.Second,
+	Control: func(network, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		if ip := net.ParseIP(host); ip == nil || IsBlockedWebhookIP(ip) {
+			return ErrWebhookAddressBlocked
+		}
+		return nil
+	},
+}
+
+// webhookClient is shared by all deliveries; receivers that take longer than the timeout are retried later.
+// Redirects are not followed so a receiver cannot bounce deliveries to another host.
+var webhookClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		Proxy:                 nil,
+		DialContext:           webhookDialer.DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+		MaxIdleConnsPerHost:   2,
+		IdleConnTimeout:       90 * time.Second,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// IsBlockedWebhookIP reports whether webhooks must not be sent to an address: loopback, private,
+// link-local, unspecified, multicast and shared (CGNAT) ranges
+func IsBlockedWebhookIP(ip net.IP) bool {
+	if len(ip) == 0 {
+		return true
+	}
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip) || ip[0] == 0
+}
+
+// CheckWebhookHost resolves the host of a webhook URL and fails if any of its addresses is blocked
+func CheckWebhookHost(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Hostname() == "" {
+		return fmt.Errorf("invalid webhook URL")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, parsed.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("webhook host could not be resolved")
+	}
+	for _, addr := range addrs {
+		if IsBlockedWebhookIP(addr.IP) {
+			return ErrWebhookAddressBlocked
+		}
+	}
+	return nil
+}
+
+// webhookTransportError turns a transport failure into a short message that is safe to show subscribers;
+// the full error is only logged
+func webhookTransportError(deliveryID uint, err error) error {
+	log.Printf("Webhook delivery %d failed: %v", deliveryID, err)
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, ErrWebhookAddressBlocked):
+		return ErrWebhookAddressBlocked
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return errors.New("request timed out")
+	default:
+		return errors.New("could not connect to receiver")
+	}
+}
+
+// WebhookRequest is one signed POST to a subscriber
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	EventID    string
+	EventType  string
+	DeliveryID uint
+	Payload    []byte
+}
+
+// SignWebhookPayload returns the X-Webhook-Signature value for a payload sent at timestamp (Unix seconds).
+// Receivers recompute HMAC-SHA256 over "<timestamp>.<body>" with their secret and compare in constant time;
+// including the timestamp lets them reject replayed requests.
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SendWebhook posts a signed payload and returns the response status.
+// Any status outside 2xx is returned as an error along with the status.
+func SendWebhook(req WebhookRequest) (int, error) {
+	timestamp := time.Now().Unix()
+
+	httpReq, err := http.NewRequest(http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("invalid webhook request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "FeelsLikeSummer-Webhooks/1.0")
+	httpReq.Header.Set("X-Webhook-Event", req.EventType)
+	httpReq.Header.Set("X-Webhook-Event-Id", req.EventID)
+	httpReq.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(req.DeliveryID), 10))
+	httpReq.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set("X-Webhook-Signature", SignWebhookPayload(req.Secret, timestamp, req.Payload))
+
+	resp, err := webhookClient.Do(httpReq)
+	if err != nil {
+		return 0, webhookTransportError(req.DeliveryID, err)
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
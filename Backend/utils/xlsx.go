@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// XLSXWriter streams rows into a single-sheet Office Open XML workbook.
+// Rows are written as inline strings so no shared string table has to be buffered.
+type XLSXWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	row   int
+}
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+const xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const xlsxSheetFooter = `</sheetData></worksheet>`
+
+// NewXLSXWriter writes the workbook scaffolding to w and opens the sheet for rows
+func NewXLSXWriter(w io.Writer, sheetName string) (*XLSXWriter, error) {
+	zw := zip.NewWriter(w)
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, xlsxEscape(xlsxSheetName(sheetName)))},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, xlsxSheetHeader); err != nil {
+		return nil, err
+	}
+
+	return &XLSXWriter{zip: zw, sheet: sheet}, nil
+}
+
+// WriteRow appends a row of text cells to the sheet
+func (x *XLSXWriter) WriteRow(cells []string) error {
+	x.row++
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, x.row)
+	for i, cell := range cells {
+		fmt.Fprintf(&b, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, xlsxColumn(i), x.row, xlsxEscape(cell))
+	}
+	b.WriteString(`</row>`)
+
+	_, err := io.WriteString(x.sheet, b.String())
+	return err
+}
+
+// Close finishes the sheet and the zip archive; it does not close the underlying writer
+func (x *XLSXWriter) Close() error {
+	if _, err := io.WriteString(x.sheet, xlsxSheetFooter); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
+
+// xlsxColumn converts a zero-based column index to its spreadsheet letters (0 -> A, 26 -> AA)
+func xlsxColumn(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// xlsxSheetName strips characters Excel forbids in sheet names and applies its 31 character limit
+func xlsxSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" {
+		name = "Sheet1"
+	}
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
+
+// xlsxEscape escapes text for XML, replacing characters XML cannot represent
+func xlsxEscape(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}